@@ -70,6 +70,9 @@ const (
 
 	blockSize = aes.BlockSize
 	keySize   = 128 / 8
+
+	// payloadMask removes the geopoint header bits
+	payloadMask = uint64(1)<<57 - 1
 )
 
 // -----------------------------------------------------------------------------
@@ -126,11 +129,51 @@ func CryptoPan(key []byte) (Strategy, error) {
 
 // -----------------------------------------------------------------------------
 
-// Anonymize anonymizes the provided point with the Crypto-PAn algorithm.
+// Anonymize anonymizes the provided point with the Crypto-PAn algorithm. The
+// point is anonymized at full precision. Crypto-PAn permutes the whole 57-bit
+// payload space, where only about 45% of the values are points below the north
+// pole; the permutation is applied again on its own output until such a point
+// is reached (cycle walking). The mapping is thus a uniform one-to-one mapping
+// of these points onto themselves. Every north pole point is anonymized as the
+// north pole at longitude 0. The empty point and invalid points are returned
+// unchanged.
 func (cp *cryptopan) Anonymize(point geopoint.Value) geopoint.Value {
-	// Encode point as bitfield
+	lat, lon, err := geopoint.Decode(point)
+	if err != nil {
+		return point
+	}
+
+	// Header bits are not part of the anonymized payload
+	v := geopoint.Encode(lat, lon)
+	switch {
+	case lat == 90 && lon >= -180 && lon < 180:
+		return geopoint.Encode(90, 0).WithFlags(geopoint.FlagAnonymized)
+	case !isPayload(v):
+		return point
+	}
+
+	// Cycle walking always ends, as the starting point is a valid one
+	for {
+		v = geopoint.Value(cp.permute(uint64(v)) & payloadMask)
+		if isPayload(v) {
+			return v.WithFlags(geopoint.FlagAnonymized)
+		}
+	}
+}
+
+// isPayload returns true if the value is a valid full precision point below
+// the north pole, without header.
+func isPayload(v geopoint.Value) bool {
+	lat, lon, err := geopoint.Decode(v)
+	return err == nil && lat < 90 && lon < 180 && geopoint.Encode(lat, lon) == v
+}
+
+// permute applies the Crypto-PAn prefix preserving permutation to the given
+// 64-bit value.
+func (cp *cryptopan) permute(value uint64) uint64 {
+	// Encode value as bitfield
 	addr := make([]byte, 8)
-	binary.BigEndian.PutUint64(addr, uint64(point))
+	binary.BigEndian.PutUint64(addr, value)
 
 	addrBits := uint(8 * 8)
 	var origAddr, input, output, toXor bitvector
@@ -166,27 +209,21 @@ func (cp *cryptopan) Anonymize(point geopoint.Value) geopoint.Value {
 		toXor[i] ^= origAddr[i]
 	}
 
-	// Decode point
-	v := geopoint.Value(binary.BigEndian.Uint64(toXor[:len(addr)]))
-	lat, lon, err := geopoint.Decode(v)
-	if err != nil {
-		panic(err)
-	}
-
-	return geopoint.Encode(lat, lon)
+	return binary.BigEndian.Uint64(toXor[:len(addr)])
 }
 
 // DeAnonymize de-anonymizes the provided point with the Crypto-PAn algorithm.
+// The empty point is returned unchanged.
 func (cp *cryptopan) DeAnonymize(point geopoint.Value) geopoint.Value {
 	// Encode point as bitfield
 	addr := make([]byte, 8)
 	binary.BigEndian.PutUint64(addr, uint64(point))
 
-	// Decode point
+	// Decode point, empty and invalid points are returned unchanged
 	v := geopoint.Value(binary.BigEndian.Uint64(addr))
 	lat, lon, err := geopoint.Decode(v)
 	if err != nil {
-		panic(err)
+		return point
 	}
 
 	return geopoint.Encode(lat, lon)
@@ -19,6 +19,8 @@
 package anonymizer_test
 
 import (
+	"encoding/json"
+	"math/rand"
 	"testing"
 
 	"go.zenithar.org/geopoint"
@@ -42,23 +44,23 @@ func TestCryptoPan(t *testing.T) {
 	}{
 		{
 			point:        geopoint.Value(75071809151126838),
-			expected:     geopoint.Value(74957365464878278),
-			expectedCode: "10A4D:53A5D:54CC6",
+			expected:     geopoint.Value(651379358708380345),
+			expectedCode: "90A2A:1359D:5B2B9",
 		},
 		{
 			point:        geopoint.Value(75071809155908323),
-			expected:     geopoint.Value(74957365459525987),
-			expectedCode: "10A4D:53A58:3A163",
+			expected:     geopoint.Value(651379358702839136),
+			expectedCode: "90A2A:13598:12560",
 		},
 		{
 			point:        geopoint.Value(77887690747650097),
-			expected:     geopoint.Value(78593030310600448),
-			expectedCode: "11737:F1D57:C1F00",
+			expected:     geopoint.Value(655053782614023936),
+			expectedCode: "91737:F1D57:C1F00",
 		},
 		{
 			point:        geopoint.Value(31659800001010902),
-			expected:     geopoint.Value(40028805103861206),
-			expectedCode: "08E35:FC74F:FBDD6",
+			expected:     geopoint.Value(580501471358492129),
+			expectedCode: "80E5B:0337E:A1DE1",
 		},
 	}
 
@@ -90,70 +92,70 @@ func TestCryptoPan_World(t *testing.T) {
 		{
 			lat:                 0,
 			lon:                 0,
-			expectedCryptoPoint: geopoint.Value(14860500948746467),
-			expectedCryptoLat:   -64.132617,
+			expectedCryptoPoint: geopoint.Value(591321253252169955),
+			expectedCryptoLat:   -63.867383,
 			expectedCryptoLon:   23.656909,
 		},
 		{
 			lat:                 0,
 			lon:                 90,
-			expectedCryptoPoint: geopoint.Value(14918225837949661),
-			expectedCryptoLat:   -64.161807,
+			expectedCryptoPoint: geopoint.Value(591378978141373149),
+			expectedCryptoLat:   -63.838193,
 			expectedCryptoLon:   76.145434,
 		},
 		{
 			lat:                 0,
 			lon:                 180,
-			expectedCryptoPoint: geopoint.Value(14650440576121728),
-			expectedCryptoLat:   -64.986960,
-			expectedCryptoLon:   -168.496120,
+			expectedCryptoPoint: geopoint.Value(591110653285228768),
+			expectedCryptoLat:   -63.901304,
+			expectedCryptoLon:   -167.950388,
 		},
 		{
 			lat:                 0,
 			lon:                 -180,
-			expectedCryptoPoint: geopoint.Value(14650440576121728),
-			expectedCryptoLat:   -64.986960,
-			expectedCryptoLon:   -168.496120,
+			expectedCryptoPoint: geopoint.Value(591110653285228768),
+			expectedCryptoLat:   -63.901304,
+			expectedCryptoLon:   -167.950388,
 		},
 		{
 			lat:                 0,
 			lon:                 -90,
-			expectedCryptoPoint: geopoint.Value(14736189554943261),
-			expectedCryptoLat:   -64.981431,
-			expectedCryptoLon:   -90.424386,
+			expectedCryptoPoint: geopoint.Value(591196941858366749),
+			expectedCryptoLat:   -63.018569,
+			expectedCryptoLon:   -89.575614,
 		},
 		{
 			lat:                 45,
 			lon:                 45,
-			expectedCryptoPoint: geopoint.Value(76384112167864092),
+			expectedCryptoPoint: geopoint.Value(652844864471287580),
 			expectedCryptoLat:   45.788406,
 			expectedCryptoLon:   170.853906,
 		},
 		{
 			lat:                 -45,
 			lon:                 -135,
-			expectedCryptoPoint: geopoint.Value(46376032511439846),
-			expectedCryptoLat:   -8.654170,
+			expectedCryptoPoint: geopoint.Value(622836505643049699),
+			expectedCryptoLat:   -7.934583,
 			expectedCryptoLon:   14.557021,
 		},
 		{
 			lat:                 90,
 			lon:                 90,
-			expectedCryptoPoint: geopoint.Value(101619606975423775),
-			expectedCryptoLat:   90.982583,
-			expectedCryptoLon:   82.491075,
+			expectedCryptoPoint: geopoint.Value(677989656012259328),
+			expectedCryptoLat:   90,
+			expectedCryptoLon:   0,
 		},
 		{
 			lat:                 90,
 			lon:                 0,
-			expectedCryptoPoint: geopoint.Value(101519575039615292),
-			expectedCryptoLat:   90.130582,
-			expectedCryptoLon:   -9.654918,
+			expectedCryptoPoint: geopoint.Value(677989656012259328),
+			expectedCryptoLat:   90,
+			expectedCryptoLon:   0,
 		},
 		{
 			lat:                 -90,
 			lon:                 0,
-			expectedCryptoPoint: geopoint.Value(71833354328081954),
+			expectedCryptoPoint: geopoint.Value(648294106631505442),
 			expectedCryptoLat:   37.161824,
 			expectedCryptoLon:   128.210965,
 		},
@@ -180,6 +182,54 @@ func TestCryptoPan_World(t *testing.T) {
 	}
 }
 
+func TestCryptoPan_Empty(t *testing.T) {
+	cpan, err := anonymizer.CryptoPan(testKey)
+	if err != nil {
+		t.Fatal("New(testKey) failed:", err)
+	}
+
+	if p := cpan.Anonymize(geopoint.Empty); p != geopoint.Empty {
+		t.Fatalf("Invalid result: empty point should be kept, got %d", uint64(p))
+	}
+	if p := cpan.DeAnonymize(geopoint.Empty); p != geopoint.Empty {
+		t.Fatalf("Invalid result: empty point should be kept, got %d", uint64(p))
+	}
+}
+
+func TestCryptoPan_Valid(t *testing.T) {
+	cpan, err := anonymizer.CryptoPan(testKey)
+	if err != nil {
+		t.Fatal("New(testKey) failed:", err)
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		p := cpan.Anonymize(geopoint.Encode(r.Float64()*180-90, r.Float64()*360-180))
+		if !p.IsValid() || !p.Flags().Has(geopoint.FlagAnonymized) {
+			t.Fatalf("Invalid result: %d should be a valid anonymized point", uint64(p))
+		}
+
+		lat, lon, err := geopoint.Decode(p)
+		if err != nil || lat < -90 || lat > 90 || lon < -180 || lon >= 180 {
+			t.Fatalf("Invalid result: %d should be a valid point, got (%f, %f) (%v)", uint64(p), lat, lon, err)
+		}
+
+		var fromText, fromJSON, fromSQL geopoint.Value
+		text, _ := p.MarshalText()
+		if err := fromText.UnmarshalText(text); err != nil || fromText != p {
+			t.Fatalf("Invalid result: text of %d should round-trip, got %d (%v)", uint64(p), uint64(fromText), err)
+		}
+		data, _ := json.Marshal(p)
+		if err := json.Unmarshal(data, &fromJSON); err != nil || fromJSON != p {
+			t.Fatalf("Invalid result: json of %d should round-trip, got %d (%v)", uint64(p), uint64(fromJSON), err)
+		}
+		value, _ := p.Value()
+		if err := fromSQL.Scan(value); err != nil || fromSQL != p {
+			t.Fatalf("Invalid result: sql value of %d should round-trip, got %d (%v)", uint64(p), uint64(fromSQL), err)
+		}
+	}
+}
+
 // -----------------------------------------------------------------------------
 
 // BenchmarkCryptopanIPv4 benchmarks annonymizing IPv4 addresses.
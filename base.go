@@ -0,0 +1,157 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package geopoint
+
+import (
+	"strings"
+)
+
+const (
+	// Crockford's Base32 alphabet, sorted in ASCII order
+	base32Alphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	// base32Length is the length of a 64bit value encoded in Base32
+	base32Length = 13
+
+	// Bitcoin's Base58 alphabet
+	base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+)
+
+var (
+	base32Decoding = decodingTable(base32Alphabet)
+	base58Decoding = decodingTable(base58Alphabet)
+)
+
+func init() {
+	// Crockford's Base32 is case-insensitive and maps ambiguous characters
+	for _, c := range base32Alphabet {
+		base32Decoding[strings.ToLower(string(c))[0]] = base32Decoding[c]
+	}
+	for c, v := range map[byte]byte{'O': 0, 'o': 0, 'I': 1, 'i': 1, 'L': 1, 'l': 1} {
+		base32Decoding[c] = v
+	}
+}
+
+func decodingTable(alphabet string) [256]byte {
+	var table [256]byte
+	for i := range table {
+		table[i] = 0xFF
+	}
+	for i := 0; i < len(alphabet); i++ {
+		table[alphabet[i]] = byte(i)
+	}
+	return table
+}
+
+// -----------------------------------------------------------------------------
+
+// Base32 returns the point encoded with Crockford's Base32 alphabet. The code
+// has a fixed length of 13 characters and sorts in the same order as the
+// numeric value.
+func (p Value) Base32() string {
+	if p == Empty {
+		return ""
+	}
+
+	var buf [base32Length]byte
+	value := uint64(p)
+	for i := base32Length - 1; i >= 0; i-- {
+		buf[i] = base32Alphabet[value&0x1F]
+		value >>= 5
+	}
+
+	return string(buf[:])
+}
+
+// ParseBase32 decodes a point encoded with Crockford's Base32 alphabet.
+// Decoding is case-insensitive, hyphens are ignored.
+func ParseBase32(raw string) (Value, error) {
+	raw = strings.ReplaceAll(raw, "-", "")
+	if len(raw) != base32Length {
+		return Empty, ErrInvalidGeoPointHash
+	}
+
+	value := uint64(0)
+	for i := 0; i < len(raw); i++ {
+		c := base32Decoding[raw[i]]
+		if c == 0xFF {
+			return Empty, ErrInvalidGeoPointHash
+		}
+		// First character only holds 4 bits
+		if i == 0 && c > 0xF {
+			return Empty, ErrInvalidGeoPointValue
+		}
+		value = value<<5 | uint64(c)
+	}
+
+	// Check point content
+	if err := validate(Value(value)); err != nil {
+		return Empty, err
+	}
+
+	return Value(value), nil
+}
+
+// -----------------------------------------------------------------------------
+
+// Base58 returns the point encoded with Bitcoin's Base58 alphabet
+func (p Value) Base58() string {
+	if p == Empty {
+		return ""
+	}
+
+	// 64bit value needs up to 11 characters
+	var buf [11]byte
+	i := len(buf)
+	value := uint64(p)
+	for {
+		i--
+		buf[i] = base58Alphabet[value%58]
+		value /= 58
+		if value == 0 {
+			break
+		}
+	}
+
+	return string(buf[i:])
+}
+
+// ParseBase58 decodes a point encoded with Bitcoin's Base58 alphabet
+func ParseBase58(raw string) (Value, error) {
+	if len(raw) == 0 || len(raw) > 11 {
+		return Empty, ErrInvalidGeoPointHash
+	}
+
+	value := uint64(0)
+	for i := 0; i < len(raw); i++ {
+		c := base58Decoding[raw[i]]
+		if c == 0xFF {
+			return Empty, ErrInvalidGeoPointHash
+		}
+		// Detect overflow
+		if value > (^uint64(0)-uint64(c))/58 {
+			return Empty, ErrInvalidGeoPointValue
+		}
+		value = value*58 + uint64(c)
+	}
+
+	// Check point content
+	if err := validate(Value(value)); err != nil {
+		return Empty, err
+	}
+
+	return Value(value), nil
+}
@@ -0,0 +1,120 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package geopoint_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"go.zenithar.org/geopoint"
+)
+
+func randomPoints(n int) []geopoint.Value {
+	r := rand.New(rand.NewSource(1))
+	points := make([]geopoint.Value, n)
+	for i := range points {
+		points[i] = geopoint.Encode(r.Float64()*180-90, r.Float64()*360-180)
+		if i%3 == 0 {
+			points[i] = points[i].Truncate(geopoint.Level(r.Intn(20)))
+		}
+	}
+	return points
+}
+
+func TestValue_Base32(t *testing.T) {
+	points := randomPoints(10000)
+
+	codes := make([]string, len(points))
+	for i, p := range points {
+		codes[i] = p.Base32()
+		if len(codes[i]) != 13 {
+			t.Fatalf("invalid code length for %d, got %s", p, codes[i])
+		}
+
+		out, err := geopoint.ParseBase32(codes[i])
+		if err != nil {
+			t.Fatalf("unable to parse %s, got error %v", codes[i], err)
+		}
+		if out != p {
+			t.Fatalf("invalid round trip for %s, expected %d, got %d", codes[i], p, out)
+		}
+	}
+
+	// Codes must sort as numeric values
+	sort.Slice(points, func(i, j int) bool { return points[i] < points[j] })
+	sort.Strings(codes)
+	for i, p := range points {
+		if p.Base32() != codes[i] {
+			t.Fatalf("invalid code order at %d, expected %s, got %s", i, p.Base32(), codes[i])
+		}
+	}
+}
+
+func TestValue_Base58(t *testing.T) {
+	for _, p := range randomPoints(10000) {
+		code := p.Base58()
+		out, err := geopoint.ParseBase58(code)
+		if err != nil {
+			t.Fatalf("unable to parse %s, got error %v", code, err)
+		}
+		if out != p {
+			t.Fatalf("invalid round trip for %s, expected %d, got %d", code, p, out)
+		}
+	}
+}
+
+func TestParseBase32(t *testing.T) {
+	tcl := []struct {
+		name          string
+		input         string
+		expectedPoint geopoint.Value
+		expectedErr   error
+	}{
+		{name: "Canonical", input: "022NND6JHJK9P", expectedPoint: geopoint.Value(75071809151126838)},
+		{name: "Ambiguous characters", input: "O22nnd6jhjk9p", expectedPoint: geopoint.Value(75071809151126838)},
+		{name: "Hyphens", input: "022NN-D6JHJ-K9P", expectedPoint: geopoint.Value(75071809151126838)},
+		{name: "Too short", input: "022NND6JHJK9", expectedPoint: geopoint.Empty, expectedErr: geopoint.ErrInvalidGeoPointHash},
+		{name: "Excluded letter", input: "022NND6JHJKUP", expectedPoint: geopoint.Empty, expectedErr: geopoint.ErrInvalidGeoPointHash},
+		{name: "Overflow", input: "G22NND6JHJK9P", expectedPoint: geopoint.Empty, expectedErr: geopoint.ErrInvalidGeoPointValue},
+		{name: "Out of range", input: "02T5M00000001", expectedPoint: geopoint.Empty, expectedErr: geopoint.ErrInvalidGeoPointValue},
+	}
+
+	for _, tc := range tcl {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := geopoint.ParseBase32(tc.input)
+			if err != tc.expectedErr {
+				t.Fatalf("Invalid result: Error expected %v, got %v.", tc.expectedErr, err)
+			}
+			if out != tc.expectedPoint {
+				t.Fatalf("Invalid result: expected %d but got %d", tc.expectedPoint, uint64(out))
+			}
+		})
+	}
+}
+
+func TestValue_Base_Empty(t *testing.T) {
+	if geopoint.Empty.Base32() != "" {
+		t.Fatalf("empty point should not have a Base32 code, got %s", geopoint.Empty.Base32())
+	}
+	if geopoint.Empty.Base58() != "" {
+		t.Fatalf("empty point should not have a Base58 code, got %s", geopoint.Empty.Base58())
+	}
+	if _, err := geopoint.ParseBase58(""); err != geopoint.ErrInvalidGeoPointHash {
+		t.Fatalf("invalid error, expected %v, got %v", geopoint.ErrInvalidGeoPointHash, err)
+	}
+}
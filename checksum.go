@@ -0,0 +1,221 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package geopoint
+
+import (
+	"strings"
+)
+
+// Checked codes are suffixed with two check characters computed over the
+// hexadecimal digits of the code, in the field of integers modulo 17:
+//
+//	10AB5:69A51:94D36-09
+//
+// With d(i) the i-th digit (i starting at 1), c1 and c2 the check characters,
+// both sums below are null:
+//
+//	S1 = d(1) + ... + d(n) + c1 + c2
+//	S2 = 1*d(1) + ... + n*d(n) + 16*c1 + 17*c2
+//
+// A single check character (Luhn mod N, Damm) detects typos but cannot tell
+// where they are. Here, a single wrong character at position j shifts S1 by
+// an error e and S2 by j*e, the position is then given by S2/S1 and the
+// character could be corrected.
+const (
+	checksumModulus  = 17
+	checksumAlphabet = "0123456789ABCDEFG"
+
+	// Weights of the check characters (17 = 0 mod 17)
+	checksumWeight1 = 16
+	checksumWeight2 = 0
+)
+
+// CheckedCode returns the point encoded as hexadecimal string followed by two
+// check characters.
+func (p Value) CheckedCode() string {
+	code := p.Code()
+	if code == "" {
+		return ""
+	}
+
+	digits := make([]int, 0, 16)
+	for i := 0; i < len(code); i++ {
+		if code[i] != ':' {
+			digits = append(digits, int(hexValue(code[i])))
+		}
+	}
+
+	c1, c2 := checksum(digits)
+
+	return code + "-" + string([]byte{checksumAlphabet[c1], checksumAlphabet[c2]})
+}
+
+// SuggestCorrection returns the checked code with a single character fixed.
+// It returns false when the code is valid, or when it could not be fixed by
+// changing exactly one character. A code with several wrong characters could
+// be mistaken for another code with a single one.
+func SuggestCorrection(raw string) (string, bool) {
+	raw = strings.ToUpper(raw)
+	if err := Check(raw); err != ErrChecksumMismatch && err != ErrInvalidGeoPointHash {
+		return "", false
+	}
+
+	// Split code and checksum
+	sep := strings.LastIndexByte(raw, '-')
+	if sep < 0 || len(raw)-sep != 3 {
+		return "", false
+	}
+
+	// Collect symbols and their position in the given string. Unknown
+	// characters are considered as erasures.
+	var (
+		symbols   []int
+		positions []int
+		weights   []int
+		erasures  []int
+	)
+	for i := 0; i < len(raw); i++ {
+		c := raw[i]
+		if c == ':' || c == '-' {
+			continue
+		}
+
+		weight := len(symbols) + 1
+		alphabet := checksumAlphabet[:16]
+		switch i {
+		case sep + 1:
+			weight, alphabet = checksumWeight1, checksumAlphabet
+		case sep + 2:
+			weight, alphabet = checksumWeight2, checksumAlphabet
+		}
+
+		v := strings.IndexByte(alphabet, c)
+		if v < 0 {
+			erasures = append(erasures, len(symbols))
+			v = 0
+		}
+		symbols = append(symbols, v)
+		positions = append(positions, i)
+		weights = append(weights, weight)
+	}
+
+	// Digits weights must not collide with check weights
+	if len(symbols)-2 >= checksumWeight1 {
+		return "", false
+	}
+
+	s1, s2 := 0, 0
+	for i, v := range symbols {
+		s1 += v
+		s2 += weights[i] * v
+	}
+	s1, s2 = s1%checksumModulus, s2%checksumModulus
+
+	// Locate error
+	index := -1
+	switch len(erasures) {
+	case 0:
+		if s1 == 0 {
+			return "", false
+		}
+		w := s2 * inverse(s1) % checksumModulus
+		for i := range weights {
+			if weights[i] == w {
+				index = i
+			}
+		}
+		if index < 0 {
+			return "", false
+		}
+	case 1:
+		index = erasures[0]
+	default:
+		return "", false
+	}
+
+	// Compute the symbol value that nullifies S1, and check S2
+	v := ((symbols[index]-s1)%checksumModulus + checksumModulus) % checksumModulus
+	if (s2+weights[index]*(v-symbols[index]))%checksumModulus != 0 {
+		return "", false
+	}
+	if weights[index] != checksumWeight1 && weights[index] != checksumWeight2 && v > 0xF {
+		return "", false
+	}
+
+	// Apply correction
+	fixed := []byte(raw)
+	fixed[positions[index]] = checksumAlphabet[v]
+	if _, err := ParseCode(string(fixed)); err != nil {
+		return "", false
+	}
+
+	return string(fixed), true
+}
+
+// -----------------------------------------------------------------------------
+
+// checksum computes check characters of the given digits
+func checksum(digits []int) (int, int) {
+	s1, s2 := 0, 0
+	for i, d := range digits {
+		s1 += d
+		s2 += (i + 1) * d
+	}
+
+	// With 16 = -1 mod 17, S2 = s2 - c1 = 0
+	c1 := s2 % checksumModulus
+	c2 := (checksumModulus - (s1+c1)%checksumModulus) % checksumModulus
+
+	return c1, c2
+}
+
+// verifyChecksum checks the given code against its check characters
+func verifyChecksum(code, check string) bool {
+	digits := make([]int, 0, 16)
+	for i := 0; i < len(code); i++ {
+		if code[i] != ':' {
+			digits = append(digits, int(hexValue(code[i])))
+		}
+	}
+
+	c1, c2 := checksum(digits)
+	check = strings.ToUpper(check)
+
+	return check[0] == checksumAlphabet[c1] && check[1] == checksumAlphabet[c2]
+}
+
+// inverse returns the multiplicative inverse of x modulo 17
+func inverse(x int) int {
+	// Fermat's little theorem: x^15 = x^-1 mod 17
+	result := 1
+	for i := 0; i < checksumModulus-2; i++ {
+		result = result * x % checksumModulus
+	}
+	return result
+}
+
+func hexValue(c byte) byte {
+	switch {
+	case c >= '0' && c <= '9':
+		return c - '0'
+	case c >= 'a' && c <= 'f':
+		return c - 'a' + 10
+	case c >= 'A' && c <= 'F':
+		return c - 'A' + 10
+	}
+	return 0
+}
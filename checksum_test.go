@@ -0,0 +1,154 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package geopoint_test
+
+import (
+	"testing"
+
+	"go.zenithar.org/geopoint"
+)
+
+func TestValue_CheckedCode(t *testing.T) {
+	tcl := []struct {
+		name         string
+		point        geopoint.Value
+		expectedCode string
+	}{
+		{
+			name:         "Place du capitole, Toulouse, France",
+			point:        geopoint.Value(75071809151126838),
+			expectedCode: "10AB5:69A51:94D36-09",
+		},
+		{
+			name:         "Tour Eiffel, Paris, France",
+			point:        geopoint.Value(77887690747650097),
+			expectedCode: "114B6:712B6:3A031-D5",
+		},
+		{
+			name:         "Truncated point",
+			point:        geopoint.Value(75071809151126838).Truncate(10),
+			expectedCode: "70AB5:69A51:8-3A",
+		},
+		{
+			name:  "Empty",
+			point: geopoint.Empty,
+		},
+	}
+
+	for _, tc := range tcl {
+		t.Run(tc.name, func(t *testing.T) {
+			code := tc.point.CheckedCode()
+			if code != tc.expectedCode {
+				t.Fatalf("invalid code, expected %s, got %s", tc.expectedCode, code)
+			}
+			if code == "" {
+				return
+			}
+			if err := geopoint.Check(code); err != nil {
+				t.Fatalf("error should not be raised, got %v", err)
+			}
+			out, err := geopoint.ParseCode(code)
+			if err != nil {
+				t.Fatalf("error should not be raised, got %v", err)
+			}
+			if out != tc.point {
+				t.Fatalf("invalid point, expected %d, got %d", tc.point, out)
+			}
+		})
+	}
+}
+
+func TestCheck_Checksum(t *testing.T) {
+	tcl := []struct {
+		name        string
+		input       string
+		expectedErr error
+	}{
+		{name: "Valid", input: "10AB5:69A51:94D36-09"},
+		{name: "Lowercase", input: "10ab5:69a51:94d36-09"},
+		{name: "Wrong digit", input: "10AB5:69A51:94D37-09", expectedErr: geopoint.ErrChecksumMismatch},
+		{name: "Wrong check character", input: "10AB5:69A51:94D36-0A", expectedErr: geopoint.ErrChecksumMismatch},
+		{name: "Transposed digits", input: "10AB5:69A15:94D36-09", expectedErr: geopoint.ErrChecksumMismatch},
+		{name: "Missing check character", input: "10AB5:69A51:94D36-0", expectedErr: geopoint.ErrInvalidGeoPointHash},
+		{name: "Invalid check character", input: "10AB5:69A51:94D36-0H", expectedErr: geopoint.ErrInvalidGeoPointHash},
+	}
+
+	for _, tc := range tcl {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := geopoint.Check(tc.input); err != tc.expectedErr {
+				t.Fatalf("Invalid result: Error expected %v, got %v.", tc.expectedErr, err)
+			}
+			if _, _, err := geopoint.FromString(tc.input); err != tc.expectedErr {
+				t.Fatalf("Invalid result: Error expected %v, got %v.", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestSuggestCorrection(t *testing.T) {
+	const alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+	for _, p := range randomPoints(200) {
+		code := p.CheckedCode()
+
+		// Every single character substitution must be detected and corrected
+		for i := 0; i < len(code); i++ {
+			if code[i] == ':' || code[i] == '-' {
+				continue
+			}
+			for j := 0; j < len(alphabet); j++ {
+				if alphabet[j] == code[i] {
+					continue
+				}
+
+				typo := []byte(code)
+				typo[i] = alphabet[j]
+				if err := geopoint.Check(string(typo)); err == nil {
+					t.Fatalf("typo %s of %s should be detected", typo, code)
+				}
+
+				fixed, ok := geopoint.SuggestCorrection(string(typo))
+				if !ok {
+					t.Fatalf("typo %s of %s should be corrected", typo, code)
+				}
+				if fixed != code {
+					t.Fatalf("invalid correction of %s, expected %s, got %s", typo, code, fixed)
+				}
+			}
+		}
+	}
+}
+
+func TestSuggestCorrection_Invalid(t *testing.T) {
+	tcl := []struct {
+		name  string
+		input string
+	}{
+		{name: "Valid code", input: "10AB5:69A51:94D36-09"},
+		{name: "Without checksum", input: "10AB5:69A51:94D37"},
+		{name: "Two unknown characters", input: "10AB5:69A51:94DXX-09"},
+		{name: "Missing character", input: "10AB5:69A51:94D3-09"},
+	}
+
+	for _, tc := range tcl {
+		t.Run(tc.name, func(t *testing.T) {
+			if fixed, ok := geopoint.SuggestCorrection(tc.input); ok {
+				t.Fatalf("no correction expected for %s, got %s", tc.input, fixed)
+			}
+		})
+	}
+}
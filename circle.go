@@ -0,0 +1,124 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package geopoint
+
+import "math"
+
+// coverMargin is the distance, in meters, added to circle coverings to absorb
+// rounding errors.
+const coverMargin = 1e-3
+
+// WithinRadius returns true if the great-circle distance between both points
+// is lower or equal to the given distance in meters. Points with a precision
+// level are tested with the south-west corner of their cell.
+func WithinRadius(center, p Value, meters float64) bool {
+	lat1, lon1, err := Decode(center)
+	if err != nil {
+		return false
+	}
+	lat2, lon2, err := Decode(p)
+	if err != nil {
+		return false
+	}
+
+	return distance(toRadians(lat1), toRadians(lon1), toRadians(lat2), toRadians(lon2)) <= meters
+}
+
+// CoverCircle returns at most maxRanges sorted and disjoint ranges containing
+// every point within the given distance in meters of the center, as tested by
+// WithinRadius. Ranges cover points without header flags, as produced by
+// Encode. Points outside the circle may be included, they must be filtered
+// with WithinRadius. A maxRanges below 1 is handled as 1.
+func CoverCircle(center Value, meters float64, maxRanges int) []Range {
+	lat, lon, err := Decode(center)
+	if err != nil || !(meters >= 0) {
+		return nil
+	}
+
+	// Bounding rectangle handles poles and antimeridian
+	bounds := Rect{MinLat: lat, MinLon: lon, MaxLat: lat, MaxLon: lon}.Expand(meters + coverMargin)
+	if meters >= math.Pi*earthRadius {
+		bounds = FullRect()
+	}
+
+	lat, lon = toRadians(lat), toRadians(lon)
+	return plainRanges(cover(rectBoxes(bounds), maxRanges, func(b coverBox) (bool, bool) {
+		min, max := boxDistances(b.rect(), lat, lon)
+		return min <= meters+coverMargin, max <= meters
+	}, false))
+}
+
+// -----------------------------------------------------------------------------
+
+// toRadians converts degrees to radians
+func toRadians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+// distance returns the great-circle distance in meters between the given
+// coordinates in radians, using the haversine formula.
+func distance(lat1, lon1, lat2, lon2 float64) float64 {
+	sinLat := math.Sin((lat2 - lat1) / 2)
+	sinLon := math.Sin((lon2 - lon1) / 2)
+	a := sinLat*sinLat + math.Cos(lat1)*math.Cos(lat2)*sinLon*sinLon
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// boxDistances returns the minimum and maximum distances in meters between the
+// given coordinates in radians and a rectangle that does not cross the
+// antimeridian.
+func boxDistances(r Rect, lat, lon float64) (float64, float64) {
+	minLat, maxLat := toRadians(r.MinLat), toRadians(r.MaxLat)
+	minLon, maxLon := toRadians(r.MinLon), toRadians(r.MaxLon)
+
+	// Farthest point is a corner, unless the antipode is inside
+	max := math.Max(
+		math.Max(distance(lat, lon, minLat, minLon), distance(lat, lon, minLat, maxLon)),
+		math.Max(distance(lat, lon, maxLat, minLon), distance(lat, lon, maxLat, maxLon)),
+	)
+	antiLon := lon + math.Pi
+	if antiLon >= math.Pi {
+		antiLon -= 2 * math.Pi
+	}
+	if -lat >= minLat && -lat <= maxLat && antiLon >= minLon && antiLon <= maxLon {
+		max = math.Pi * earthRadius
+	}
+
+	// Nearest point is on the center meridian, or on a meridian edge
+	if lon >= minLon && lon <= maxLon {
+		nearest := math.Max(minLat, math.Min(maxLat, lat))
+		return distance(lat, lon, nearest, lon), max
+	}
+
+	return math.Min(meridianDistance(lat, lon, minLat, maxLat, minLon), meridianDistance(lat, lon, minLat, maxLat, maxLon)), max
+}
+
+// meridianDistance returns the distance in meters between the given
+// coordinates and a meridian segment, in radians.
+func meridianDistance(lat, lon, minLat, maxLat, meridian float64) float64 {
+	d := math.Min(distance(lat, lon, minLat, meridian), distance(lat, lon, maxLat, meridian))
+
+	// Nearest point of the meridian great circle
+	if dLon := math.Remainder(lon-meridian, 2*math.Pi); math.Abs(dLon) < math.Pi/2 {
+		nearest := math.Atan(math.Tan(lat) / math.Cos(dLon))
+		if nearest > minLat && nearest < maxLat {
+			d = math.Min(d, distance(lat, lon, nearest, meridian))
+		}
+	}
+
+	return d
+}
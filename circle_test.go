@@ -0,0 +1,166 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package geopoint_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"go.zenithar.org/geopoint"
+)
+
+func TestWithinRadius(t *testing.T) {
+	// One degree on the mean earth radius
+	degree := 6371008.8 * math.Pi / 180
+
+	tcl := []struct {
+		name     string
+		a, b     geopoint.Value
+		meters   float64
+		expected bool
+	}{
+		{name: "Same point", a: geopoint.Encode(43.603574, 1.442917), b: geopoint.Encode(43.603574, 1.442917), meters: 0, expected: true},
+		{name: "Equator inside", a: geopoint.Encode(0, 0), b: geopoint.Encode(0, 1), meters: degree + 0.001, expected: true},
+		{name: "Equator outside", a: geopoint.Encode(0, 0), b: geopoint.Encode(0, 1), meters: degree - 0.001},
+		{name: "Antimeridian", a: geopoint.Encode(0, 179.5), b: geopoint.Encode(0, -179.5), meters: degree + 0.001, expected: true},
+		{name: "Pole", a: geopoint.Encode(89.5, 0), b: geopoint.Encode(89.5, 180), meters: degree + 0.001, expected: true},
+		{name: "Toulouse Paris", a: geopoint.Encode(43.604652, 1.444209), b: geopoint.Encode(48.856614, 2.352222), meters: 580000},
+		{name: "Empty", a: geopoint.Empty, b: geopoint.Encode(0, 0), meters: 1000},
+	}
+
+	for _, tc := range tcl {
+		t.Run(tc.name, func(t *testing.T) {
+			if out := geopoint.WithinRadius(tc.a, tc.b, tc.meters); out != tc.expected {
+				t.Fatalf("Invalid result: expected %v but got %v", tc.expected, out)
+			}
+			if out := geopoint.WithinRadius(tc.b, tc.a, tc.meters); out != tc.expected {
+				t.Fatalf("Invalid result: expected %v but got %v (reversed)", tc.expected, out)
+			}
+		})
+	}
+}
+
+func TestCoverCircle_BruteForce(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	centers := []geopoint.Value{
+		geopoint.Encode(43.603574, 1.442917),
+		geopoint.Encode(0, 179.999),
+		geopoint.Encode(-12.5, -180),
+		geopoint.Encode(89.99, 45),
+		geopoint.Encode(-90, 0),
+		geopoint.Encode(70, -179.9),
+	}
+	for i := 0; i < 20; i++ {
+		centers = append(centers, geopoint.Encode(r.Float64()*180-90, r.Float64()*360-180))
+	}
+
+	for _, center := range centers {
+		lat, lon, _ := geopoint.Decode(center)
+		for _, meters := range []float64{2000, 50000, 800000} {
+			// Random points around the center
+			span := 2 * meters / 111195
+			points := make([]geopoint.Value, 5000)
+			for i := range points {
+				p, _ := geopoint.EncodeWithMode(lat+(r.Float64()*2-1)*span, lon+(r.Float64()*2-1)*span/math.Max(0.01, math.Cos(lat*math.Pi/180)), geopoint.Lenient)
+				points[i] = p
+			}
+
+			for _, maxRanges := range []int{1, 8, 64} {
+				ranges := geopoint.CoverCircle(center, meters, maxRanges)
+				if len(ranges) > maxRanges || len(ranges) == 0 {
+					t.Fatalf("Invalid result: expected at most %d ranges, got %d", maxRanges, len(ranges))
+				}
+				for j := 1; j < len(ranges); j++ {
+					if ranges[j].Min <= ranges[j-1].Max {
+						t.Fatalf("Invalid result: ranges should be sorted and disjoint, got %v", ranges)
+					}
+				}
+
+				inside := 0
+				for _, p := range points {
+					if !geopoint.WithinRadius(center, p, meters) {
+						continue
+					}
+					inside++
+					found := false
+					for _, rg := range ranges {
+						found = found || rg.Contains(p)
+					}
+					if !found {
+						t.Fatalf("Invalid result: %f should be covered by %v for %f within %fm", p, ranges, center, meters)
+					}
+				}
+				if inside == 0 {
+					t.Fatalf("Invalid result: no random point in the circle of %f", center)
+				}
+			}
+		}
+	}
+}
+
+func TestCoverCircle_FalsePositives(t *testing.T) {
+	center := geopoint.Encode(43.603574, 1.442917)
+	ranges := geopoint.CoverCircle(center, 2000, 32)
+
+	// Points in a 10km square
+	r := rand.New(rand.NewSource(1))
+	covered, outside := 0, 0
+	for i := 0; i < 20000; i++ {
+		p := geopoint.Encode(43.603574+(r.Float64()*2-1)*0.045, 1.442917+(r.Float64()*2-1)*0.062)
+		in := false
+		for _, rg := range ranges {
+			in = in || rg.Contains(p)
+		}
+		if in {
+			covered++
+			if !geopoint.WithinRadius(center, p, 2000) {
+				outside++
+			}
+		}
+	}
+
+	if ratio := float64(outside) / float64(covered); ratio > 0.25 {
+		t.Fatalf("Invalid result: too many false positives, got %f", ratio)
+	}
+}
+
+func TestCoverCircle_Empty(t *testing.T) {
+	if out := geopoint.CoverCircle(geopoint.Empty, 1000, 8); out != nil {
+		t.Fatalf("Invalid result: empty point should have no cover, got %v", out)
+	}
+	if out := geopoint.CoverCircle(geopoint.Encode(0, 0), -1, 8); out != nil {
+		t.Fatalf("Invalid result: negative radius should have no cover, got %v", out)
+	}
+
+	// Whole earth
+	out := geopoint.CoverCircle(geopoint.Encode(0, 0), 30000000, 8)
+	if len(out) != 1 || out[0].Min != geopoint.Encode(-90, -180) || out[0].Max != geopoint.Encode(90, 179.999999) {
+		t.Fatalf("Invalid result: expected a single range, got %v", out)
+	}
+}
+
+func TestCoverCircle_MaxRanges(t *testing.T) {
+	center := geopoint.Encode(43.603574, 1.442917)
+	for _, maxRanges := range []int{0, -1} {
+		out := geopoint.CoverCircle(center, 1000, maxRanges)
+		if len(out) != 1 || !out[0].Contains(center) {
+			t.Fatalf("Invalid result for %d ranges: expected a single range, got %v", maxRanges, out)
+		}
+	}
+}
@@ -17,104 +17,280 @@
 package geopoint
 
 import (
-	"fmt"
+	"math"
+	"math/bits"
 	"regexp"
 	"strconv"
 	"strings"
 )
 
 var (
-	geoPointRegex = regexp.MustCompile("^[0-9A-Za-z]{5}:[0-9A-Za-z]{5}:[0-9A-Za-z]{5}$")
+	geoPointRegex = regexp.MustCompile("^[0-9A-Fa-f]{5,6}:([0-9A-Fa-f]{5}:[0-9A-Fa-f]{1,5}|[0-9A-Fa-f]{1,5})$")
+	checksumRegex = regexp.MustCompile("^[0-9A-Ga-g]{2}$")
 )
 
 // Encode a point using Crypto-PAn algorithm
 func Encode(latitude float64, longitude float64) Value {
 
-	// Render as string (precision 10^6)
-	coords := fmt.Sprintf("%.6f.%.6f", latitude, longitude)
+	// Convert to micro-degrees (precision 10^6) and rebase to a non-negative
+	// scale, the offset keeps coordinates ordered across both hemispheres.
+	lat := toMicroDegrees(latitude) + 90*microDegrees                            // [0; 180*10^6]
+	lon := (toMicroDegrees(longitude) + 180*microDegrees) % (360 * microDegrees) // [0; 360*10^6[
 
 	// Split in parts
-	parts := strings.SplitN(coords, ".", 4)
-
-	// Decode parts as integer
-	highLat, _ := strconv.Atoi(parts[0]) // [-90; 90] => log2(180) => 8bits
-	highLat = (highLat + 90)             // Rebase to non-negative scale
-	lowLat, _ := strconv.Atoi(parts[1])  // 10^6 => log2(10^6) => 20bits
-	highLon, _ := strconv.Atoi(parts[2]) // [-180; 180] => log2(360) => 9bits
-	highLon = ((highLon + 180) % 360)    // Rebase to non-negative scale
-	lowLon, _ := strconv.Atoi(parts[3])  // 10^6 => log2(10^6) => 20bits
+	highLat := lat / microDegrees // [0; 180] => log2(180) => 8bits
+	lowLat := lat % microDegrees  // 10^6 => log2(10^6) => 20bits
+	highLon := lon / microDegrees // [0; 360[ => log2(360) => 9bits
+	lowLon := lon % microDegrees  // 10^6 => log2(10^6) => 20bits
 
 	// Fill an uint64
 	encoded := uint64(0)
 
 	// Interleave LSB
-	encoded |= interleave((uint32(lowLat)&0xFFFFF), uint32(lowLon&0xFFFFF)) & 0xFFFFFFFFFF
-	// Rebase longitude to 0 to remove sign
+	encoded |= interleave(uint32(lowLat)&0xFFFFF, uint32(lowLon)&0xFFFFF) & 0xFFFFFFFFFF
+	// Longitude degrees
 	encoded = encoded | uint64((highLon&0x1FF)<<40)
-	// Rebase latitude to 0 to remove sign
+	// Latitude degrees
 	encoded = encoded | uint64((highLat&0xFF)<<49)
 
 	// Return a point
 	return Value(encoded)
 }
 
-// Decode a point to retrieve (lat,lon)
-func Decode(raw Value) (float64, float64, error) {
+// Mode defines how EncodeWithMode handles out of range coordinates
+type Mode uint8
 
-	value := uint64(raw)
+const (
+	// Strict rejects latitudes outside [-90; 90] and longitudes outside
+	// [-180; 180]
+	Strict Mode = iota
+	// Lenient clamps latitudes to [-90; 90] and wraps longitudes to
+	// [-180; 180[
+	Lenient
+)
 
-	// Decode packed value
-	highLat := int64((value>>49)&0xFF - 90)       // Center origin [-90;90]
-	highLon := int64((value>>40)&0x1FF-180) % 360 // Center origin [-180;180]
-	lowLat, lowLon := deinterleave(uint64(value & 0xFFFFFFFFFF))
+// EncodeChecked validates and encodes a point, coordinates out of range are
+// rejected.
+func EncodeChecked(latitude float64, longitude float64) (Value, error) {
+	return EncodeWithMode(latitude, longitude, Strict)
+}
 
-	// Assemble values
-	latStr := fmt.Sprintf("%d.%d", highLat, lowLat)
-	lonStr := fmt.Sprintf("%d.%d", highLon, lowLon)
+// EncodeWithMode validates and encodes a point, out of range coordinates are
+// handled according to the given mode. Non-finite coordinates are always
+// rejected.
+func EncodeWithMode(latitude float64, longitude float64, mode Mode) (Value, error) {
+	// Check finite values
+	if math.IsNaN(latitude) || math.IsInf(latitude, 0) {
+		return Empty, ErrNotFinite(latitude)
+	}
+	if math.IsNaN(longitude) || math.IsInf(longitude, 0) {
+		return Empty, ErrNotFinite(longitude)
+	}
 
-	// Extract float values from string
-	lat, err := strconv.ParseFloat(latStr, 64)
-	if err != nil {
-		return 0, 0, ErrInvalidGeoPointValue
+	switch mode {
+	case Lenient:
+		latitude = math.Max(-90, math.Min(90, latitude))
+		longitude = math.Mod(longitude+180, 360)
+		if longitude < 0 {
+			longitude += 360
+		}
+		longitude -= 180
+	default:
+		if latitude < -90 || latitude > 90 {
+			return Empty, ErrLatitudeOutOfRange(latitude)
+		}
+		if longitude < -180 || longitude > 180 {
+			return Empty, ErrLongitudeOutOfRange(longitude)
+		}
 	}
-	lon, err := strconv.ParseFloat(lonStr, 64)
-	if err != nil {
-		return 0, 0, ErrInvalidGeoPointValue
+
+	// Delegate to encoder
+	return Encode(latitude, longitude), nil
+}
+
+// Decode a point to retrieve (lat,lon). Coordinates are rebuilt from integer
+// micro-degrees, so every value produced by Encode decodes to the nearest
+// float64 of its 6-digit decimal representation. Points with a precision level
+// are decoded as the south-west corner of their cell.
+func Decode(raw Value) (float64, float64, error) {
+
+	// Empty point has no coordinates
+	if raw == Empty {
+		return 0, 0, ErrEmptyGeoPoint
 	}
 
-	return lat, lon, nil
+	value := uint64(raw.origin())
+
+	// Decode packed value
+	highLat := int64((value >> 49) & 0xFF)
+	highLon := int64((value >> 40) & 0x1FF)
+	lowLat, lowLon := deinterleave(value & 0xFFFFFFFFFF)
+
+	// Assemble values and center origin
+	lat := highLat*microDegrees + int64(lowLat) - 90*microDegrees  // [-90;90]
+	lon := highLon*microDegrees + int64(lowLon) - 180*microDegrees // [-180;180[
+
+	return fromMicroDegrees(lat), fromMicroDegrees(lon), nil
 }
 
-// Check the given encoded point
+// Check the given encoded point, check characters are verified when present
 func Check(raw string) error {
+	// Split check characters
+	raw, check, checked := splitChecksum(raw)
+
 	// Check
 	if !geoPointRegex.MatchString(raw) {
 		return ErrInvalidGeoPointHash
 	}
 
+	// Version nibble is only printed for versions other than the current one
+	if raw[5] != ':' {
+		if raw[0] == '0' {
+			return ErrInvalidGeoPointHash
+		}
+		return ErrUnsupportedVersion
+	}
+
+	// Verify checksum
+	if checked {
+		if !checksumRegex.MatchString(check) {
+			return ErrInvalidGeoPointHash
+		}
+		if !verifyChecksum(raw, check) {
+			return ErrChecksumMismatch
+		}
+	}
+
 	// Syntaxically correct
 	return nil
 }
 
-// FromString a point to retrieve (lat,lon)
-func FromString(raw string) (float64, float64, error) {
+// ParseCode decodes the given encoded point, with or without check
+// characters.
+func ParseCode(raw string) (Value, error) {
 
 	// Check given raw string
 	if err := Check(raw); err != nil {
-		return 0, 0, err
+		return Empty, err
 	}
+	raw, _, _ = splitChecksum(raw)
 
 	// Remove all ':'
 	raw = strings.ReplaceAll(raw, ":", "")
+	digits := len(raw) - 5
 
 	// Decode hexadecimal
 	value, err := strconv.ParseUint(raw, 16, 64)
 	if err != nil {
-		return 0, 0, ErrInvalidGeoPointValue
+		return Empty, ErrInvalidGeoPointValue
+	}
+
+	// Align significant digits
+	value <<= 4 * uint(10-digits)
+
+	// Check point content
+	if err := validate(Value(value)); err != nil {
+		return Empty, err
+	}
+
+	// Check that only significant digits were given
+	if Value(value).codeDigits() != digits {
+		return Empty, ErrInvalidGeoPointValue
+	}
+
+	return Value(value), nil
+}
+
+// FromString a point to retrieve (lat,lon). The point could be given as its
+// hexadecimal code, or as its Base32 or Base58 form.
+func FromString(raw string) (float64, float64, error) {
+
+	var (
+		value Value
+		err   error
+	)
+
+	// Detect used alphabet
+	switch {
+	case strings.Contains(raw, ":"):
+		value, err = ParseCode(raw)
+	case len(strings.ReplaceAll(raw, "-", "")) == base32Length:
+		value, err = ParseBase32(raw)
+	default:
+		value, err = ParseBase58(raw)
+	}
+	if err != nil {
+		return 0, 0, err
 	}
 
 	// Delegate to decoder
-	return Decode(Value(value))
+	return Decode(value)
+}
+
+// splitChecksum splits the given code and its check characters
+func splitChecksum(raw string) (string, string, bool) {
+	idx := strings.LastIndexByte(raw, '-')
+	if idx < 0 {
+		return raw, "", false
+	}
+	return raw[:idx], raw[idx+1:], true
+}
+
+// validate checks that every packed field of the given point is in range
+func validate(raw Value) error {
+
+	value := uint64(raw)
+
+	// Check header
+	if raw.Version() != Version {
+		return ErrUnsupportedVersion
+	}
+	if raw.Flags().Has(FlagTruncated) && !raw.Flags().Has(FlagPrecision) {
+		return ErrInvalidGeoPointValue
+	}
+	value &= payloadMask
+
+	// Level marker must be set on an odd bit
+	if raw.Flags().Has(FlagPrecision) {
+		low := value & 0xFFFFFFFFFF
+		if low == 0 || bits.TrailingZeros64(low)%2 == 0 {
+			return ErrInvalidGeoPointValue
+		}
+		value = uint64(raw.origin()) & payloadMask
+	}
+
+	highLat := (value >> 49) & 0xFF
+	highLon := (value >> 40) & 0x1FF
+	lowLat, lowLon := deinterleave(value & 0xFFFFFFFFFF)
+
+	switch {
+	case highLat > 180 || highLon >= 360:
+		return ErrInvalidGeoPointValue
+	case lowLat >= microDegrees || lowLon >= microDegrees:
+		return ErrInvalidGeoPointValue
+	case highLat == 180 && lowLat != 0:
+		return ErrInvalidGeoPointValue
+	}
+
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+
+// microDegrees is the fixed-point scale used to store coordinates
+const microDegrees = 1000000
+
+// toMicroDegrees converts a coordinate in degrees to the nearest integer
+// micro-degree.
+func toMicroDegrees(degrees float64) int64 {
+	return int64(math.Round(degrees * microDegrees))
+}
+
+// fromMicroDegrees converts micro-degrees to degrees. Both operands are exact
+// in float64, so the division is correctly rounded and returns the same value
+// as parsing the 6-digit decimal representation.
+func fromMicroDegrees(micro int64) float64 {
+	return float64(micro) / microDegrees
 }
 
 // -----------------------------------------------------------------------------
@@ -17,6 +17,8 @@
 package geopoint_test
 
 import (
+	"math"
+	"math/rand"
 	"testing"
 
 	"go.zenithar.org/geopoint"
@@ -46,56 +48,71 @@ func TestEncoder_Encode(t *testing.T) {
 		lon            float64
 		expectedPoint  geopoint.Value
 		expectedCode   string
+		expectedBase32 string
 		expectedBase58 string
 	}{
 		{
-			name:          "Pôle Nord",
-			lat:           90,
-			lon:           0,
-			expectedPoint: geopoint.Value(101528903708835840),
-			expectedCode:  "168B4:00000:00000",
+			name:           "Pôle Nord",
+			lat:            90,
+			lon:            0,
+			expectedPoint:  geopoint.Value(101528903708835840),
+			expectedCode:   "168B4:00000:00000",
+			expectedBase32: "02T5M00000000",
+			expectedBase58: "Efod8zMTFV",
 		},
 		{
-			name:          "Pôle Sud",
-			lat:           -90,
-			lon:           0,
-			expectedPoint: geopoint.Value(197912092999680),
-			expectedCode:  "000B4:00000:00000",
+			name:           "Pôle Sud",
+			lat:            -90,
+			lon:            0,
+			expectedPoint:  geopoint.Value(197912092999680),
+			expectedCode:   "000B4:00000:00000",
+			expectedBase32: "0005M00000000",
+			expectedBase58: "2YdpGwY2j",
 		},
 		{
-			name:          "Place du capitole, Toulouse, France",
-			lat:           43.603574,
-			lon:           1.442917,
-			expectedPoint: geopoint.Value(75071809151126838),
-			expectedCode:  "10AB5:69A51:94D36",
+			name:           "Place du capitole, Toulouse, France",
+			lat:            43.603574,
+			lon:            1.442917,
+			expectedPoint:  geopoint.Value(75071809151126838),
+			expectedCode:   "10AB5:69A51:94D36",
+			expectedBase32: "022NND6JHJK9P",
+			expectedBase58: "B7DA8NMQkm",
 		},
 		{
-			name:          "Mairie de Toulouse, Toulouse, France",
-			lat:           43.604297,
-			lon:           1.443677,
-			expectedPoint: geopoint.Value(75071809155908323),
-			expectedCode:  "10AB5:69A56:242E3",
+			name:           "Mairie de Toulouse, Toulouse, France",
+			lat:            43.604297,
+			lon:            1.443677,
+			expectedPoint:  geopoint.Value(75071809155908323),
+			expectedCode:   "10AB5:69A56:242E3",
+			expectedBase32: "022NND6JP4GQ3",
+			expectedBase58: "B7DA8Nmv8A",
 		},
 		{
-			name:          "Tour Eiffel, Paris, France",
-			lat:           48.858373,
-			lon:           2.292292,
-			expectedPoint: geopoint.Value(77887690747650097),
-			expectedCode:  "114B6:712B6:3A031",
+			name:           "Tour Eiffel, Paris, France",
+			lat:            48.858373,
+			lon:            2.292292,
+			expectedPoint:  geopoint.Value(77887690747650097),
+			expectedCode:   "114B6:712B6:3A031",
+			expectedBase32: "0255PE4NP781H",
+			expectedBase58: "BVCUZaWBXe",
 		},
 		{
-			name:          "Montréal, Quebec, Canada",
-			lat:           45.558196,
-			lon:           -73.870384,
-			expectedPoint: geopoint.Value(76116863733120784),
-			expectedCode:  "10E6B:E2603:ABF10",
+			name:           "Montréal, Quebec, Canada",
+			lat:            45.558196,
+			lon:            -73.870384,
+			expectedPoint:  geopoint.Value(76115079348107024),
+			expectedCode:   "10E6A:42EA9:83710",
+			expectedBase32: "023KA8BN9GDRG",
+			expectedBase58: "BFMf4q9b8K",
 		},
 		{
-			name:          "Buenos Aires, Argentina",
-			lat:           -34.615662,
-			lon:           -58.503337,
-			expectedPoint: geopoint.Value(31659800001010902),
-			expectedCode:  "0707A:6B9CB:85CD6",
+			name:           "Buenos Aires, Argentina",
+			lat:            -34.615662,
+			lon:            -58.503337,
+			expectedPoint:  geopoint.Value(31095545295606574),
+			expectedCode:   "06E79:3BD37:1132E",
+			expectedBase32: "00VKS7F9Q24SE",
+			expectedBase58: "5BpEFH5fWd",
 		},
 	}
 
@@ -108,6 +125,12 @@ func TestEncoder_Encode(t *testing.T) {
 			if out.Code() != tc.expectedCode {
 				t.Fatalf("Invalid result: expected %s but got %s", tc.expectedCode, out.Code())
 			}
+			if out.Base32() != tc.expectedBase32 {
+				t.Fatalf("Invalid result: expected %s but got %s", tc.expectedBase32, out.Base32())
+			}
+			if out.Base58() != tc.expectedBase58 {
+				t.Fatalf("Invalid result: expected %s but got %s", tc.expectedBase58, out.Base58())
+			}
 		})
 	}
 }
@@ -141,13 +164,13 @@ func TestEncoder_Decode(t *testing.T) {
 		},
 		{
 			name:        "Montréal, Quebec, Canada",
-			point:       geopoint.Value(76116863733120784),
+			point:       geopoint.Value(76115079348107024),
 			expectedLat: 45.558196,
 			expectedLon: -73.870384,
 		},
 		{
 			name:        "Buenos Aires, Argentina",
-			point:       geopoint.Value(31659800001010902),
+			point:       geopoint.Value(31095545295606574),
 			expectedLat: -34.615662,
 			expectedLon: -58.503337,
 		},
@@ -198,21 +221,89 @@ func TestEncoder_DecodeString(t *testing.T) {
 		},
 		{
 			name:        "Montréal, Quebec, Canada",
-			input:       "10E6B:E2603:ABF10",
+			input:       "10E6A:42EA9:83710",
 			expectedLat: 45.558196,
 			expectedLon: -73.870384,
 		},
 		{
 			name:        "Buenos Aires, Argentina",
-			input:       "0707A:6B9CB:85CD6",
+			input:       "06E79:3BD37:1132E",
 			expectedLat: -34.615662,
 			expectedLon: -58.503337,
 		},
 		{
 			name:        "CryptoPan - Place du capitole, Toulouse, France",
-			input:       "10A4D:53A5D:54CC6",
+			input:       "90A4D:53A5D:54CC6",
 			expectedLat: 43.868266,
-			expectedLon: -103.116777,
+			expectedLon: -102.883223,
+		},
+		{
+			name:        "Base32 - Place du capitole, Toulouse, France",
+			input:       "022NND6JHJK9P",
+			expectedLat: 43.603574,
+			expectedLon: 1.442917,
+		},
+		{
+			name:        "Base32 lowercase - Place du capitole, Toulouse, France",
+			input:       "o22nnd6-jhjk9p",
+			expectedLat: 43.603574,
+			expectedLon: 1.442917,
+		},
+		{
+			name:        "Base58 - Place du capitole, Toulouse, France",
+			input:       "B7DA8NMQkm",
+			expectedLat: 43.603574,
+			expectedLon: 1.442917,
+		},
+		{
+			name:        "Invalid Base32",
+			input:       "022NND6JHJK9U",
+			expectedErr: geopoint.ErrInvalidGeoPointHash,
+		},
+		{
+			name:        "Invalid Base58",
+			input:       "B7DA8NMQk0",
+			expectedErr: geopoint.ErrInvalidGeoPointHash,
+		},
+		{
+			name:        "Base58 overflow",
+			input:       "zzzzzzzzzzz",
+			expectedErr: geopoint.ErrInvalidGeoPointValue,
+		},
+		{
+			name:        "Invalid syntax",
+			input:       "10AB5:69A51:94D36:0",
+			expectedErr: geopoint.ErrInvalidGeoPointHash,
+		},
+		{
+			name:        "Non hexadecimal",
+			input:       "10AB5:69A51:94DZZ",
+			expectedErr: geopoint.ErrInvalidGeoPointHash,
+		},
+		{
+			name:        "Latitude above north pole",
+			input:       "168B4:00000:00001",
+			expectedErr: geopoint.ErrInvalidGeoPointValue,
+		},
+		{
+			name:        "Longitude out of range",
+			input:       "10BFF:00000:00000",
+			expectedErr: geopoint.ErrInvalidGeoPointValue,
+		},
+		{
+			name:        "Truncated without precision",
+			input:       "50AB5:69A51:94D36",
+			expectedErr: geopoint.ErrInvalidGeoPointValue,
+		},
+		{
+			name:        "Unknown version",
+			input:       "110AB5:69A51:94D36",
+			expectedErr: geopoint.ErrUnsupportedVersion,
+		},
+		{
+			name:        "Non canonical version",
+			input:       "010AB5:69A51:94D36",
+			expectedErr: geopoint.ErrInvalidGeoPointHash,
 		},
 	}
 
@@ -234,16 +325,311 @@ func TestEncoder_DecodeString(t *testing.T) {
 
 // -----------------------------------------------------------------------------
 
+func TestEncoder_EncodeChecked(t *testing.T) {
+	tcl := []struct {
+		name          string
+		lat           float64
+		lon           float64
+		expectedPoint geopoint.Value
+		expectedErr   error
+	}{
+		{
+			name:          "Place du capitole, Toulouse, France",
+			lat:           43.603574,
+			lon:           1.442917,
+			expectedPoint: geopoint.Value(75071809151126838),
+		},
+		{
+			name:          "Antimeridian",
+			lat:           0,
+			lon:           180,
+			expectedPoint: geopoint.Encode(0, -180),
+		},
+		{
+			name:          "Latitude above north pole",
+			lat:           90.5,
+			lon:           0,
+			expectedPoint: geopoint.Empty,
+			expectedErr:   geopoint.ErrLatitudeOutOfRange(90.5),
+		},
+		{
+			name:          "Latitude below south pole",
+			lat:           -91,
+			lon:           0,
+			expectedPoint: geopoint.Empty,
+			expectedErr:   geopoint.ErrLatitudeOutOfRange(-91),
+		},
+		{
+			name:          "Longitude out of range",
+			lat:           0,
+			lon:           -180.000001,
+			expectedPoint: geopoint.Empty,
+			expectedErr:   geopoint.ErrLongitudeOutOfRange(-180.000001),
+		},
+		{
+			name:          "Infinite latitude",
+			lat:           math.Inf(1),
+			lon:           0,
+			expectedPoint: geopoint.Empty,
+			expectedErr:   geopoint.ErrNotFinite(math.Inf(1)),
+		},
+		{
+			name:          "Infinite longitude",
+			lat:           0,
+			lon:           math.Inf(-1),
+			expectedPoint: geopoint.Empty,
+			expectedErr:   geopoint.ErrNotFinite(math.Inf(-1)),
+		},
+	}
+
+	for _, tc := range tcl {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := geopoint.EncodeChecked(tc.lat, tc.lon)
+			if err != tc.expectedErr {
+				t.Fatalf("Invalid result: Error expected %v, got %v.", tc.expectedErr, err)
+			}
+			if out != tc.expectedPoint {
+				t.Fatalf("Invalid result: expected %d but got %d", tc.expectedPoint, uint64(out))
+			}
+		})
+	}
+}
+
+func TestEncoder_EncodeChecked_NaN(t *testing.T) {
+	_, err := geopoint.EncodeChecked(math.NaN(), 0)
+	if _, ok := err.(geopoint.ErrNotFinite); !ok {
+		t.Fatalf("Invalid result: expected ErrNotFinite error, got %v", err)
+	}
+	_, err = geopoint.EncodeWithMode(0, math.NaN(), geopoint.Lenient)
+	if _, ok := err.(geopoint.ErrNotFinite); !ok {
+		t.Fatalf("Invalid result: expected ErrNotFinite error, got %v", err)
+	}
+}
+
+func TestEncoder_EncodeLenient(t *testing.T) {
+	tcl := []struct {
+		name        string
+		lat         float64
+		lon         float64
+		expectedLat float64
+		expectedLon float64
+	}{
+		{name: "In range", lat: 43.603574, lon: 1.442917, expectedLat: 43.603574, expectedLon: 1.442917},
+		{name: "Above north pole", lat: 91, lon: 10, expectedLat: 90, expectedLon: 10},
+		{name: "Below south pole", lat: -1000, lon: 10, expectedLat: -90, expectedLon: 10},
+		{name: "Antimeridian", lat: 0, lon: 180, expectedLat: 0, expectedLon: -180},
+		{name: "East of antimeridian", lat: 0, lon: 181.5, expectedLat: 0, expectedLon: -178.5},
+		{name: "West of antimeridian", lat: 0, lon: -181.5, expectedLat: 0, expectedLon: 178.5},
+		{name: "Several turns", lat: 0, lon: 1081.442917, expectedLat: 0, expectedLon: 1.442917},
+		{name: "Several turns backward", lat: 0, lon: -718.557083, expectedLat: 0, expectedLon: 1.442917},
+	}
+
+	for _, tc := range tcl {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := geopoint.EncodeWithMode(tc.lat, tc.lon, geopoint.Lenient)
+			if err != nil {
+				t.Fatalf("error should not be raised, got %v", err)
+			}
+			lat, lon, err := geopoint.Decode(out)
+			if err != nil {
+				t.Fatalf("error should not be raised, got %v", err)
+			}
+			if lat != tc.expectedLat {
+				t.Fatalf("Invalid result: expected latitude %0.10f but got %0.10f", tc.expectedLat, lat)
+			}
+			if lon != tc.expectedLon {
+				t.Fatalf("Invalid result: expected longitude %0.10f but got %0.10f", tc.expectedLon, lon)
+			}
+		})
+	}
+}
+
+func TestEncoder_Sign(t *testing.T) {
+	tcl := []struct {
+		name string
+		lat  float64
+		lon  float64
+	}{
+		{name: "Gulf of Guinea", lat: 0.5, lon: 0.5},
+		{name: "South of the equator", lat: -0.5, lon: 0.5},
+		{name: "West of Greenwich", lat: 0.5, lon: -0.5},
+		{name: "South west of Null Island", lat: -0.5, lon: -0.5},
+		{name: "Micro degree south west of Null Island", lat: -0.000001, lon: -0.000001},
+		{name: "London", lat: 51.507351, lon: -0.127758},
+		{name: "Accra", lat: 5.603717, lon: -0.186964},
+	}
+
+	seen := map[geopoint.Value]string{}
+	for _, tc := range tcl {
+		t.Run(tc.name, func(t *testing.T) {
+			p := geopoint.Encode(tc.lat, tc.lon)
+			if other, ok := seen[p]; ok {
+				t.Fatalf("collision with %s for %d", other, uint64(p))
+			}
+			seen[p] = tc.name
+
+			lat, lon, err := geopoint.Decode(p)
+			if err != nil {
+				t.Fatalf("error should not be raised, got %v", err)
+			}
+			if lat != tc.lat {
+				t.Fatalf("invalid latitude, expected %v, got %v", tc.lat, lat)
+			}
+			if lon != tc.lon {
+				t.Fatalf("invalid longitude, expected %v, got %v", tc.lon, lon)
+			}
+		})
+	}
+}
+
+func TestEncoder_RoundTrip(t *testing.T) {
+	roundTrip := func(lat, lon float64) {
+		pLat, pLon, err := geopoint.Decode(geopoint.Encode(lat, lon))
+		if err != nil {
+			t.Fatalf("error should not be raised, got %v", err)
+		}
+		if pLat != lat || pLon != lon {
+			t.Fatalf("invalid round trip, expected (%v,%v), got (%v,%v)", lat, lon, pLat, pLon)
+		}
+	}
+
+	// Sweep every micro-degree across the equator, on both sides of the
+	// Greenwich meridian and of the antimeridian.
+	for _, lon := range []float64{-179.999999, -0.5, 0, 0.5, 179.999999} {
+		for micro := int64(-1500000); micro <= 1500000; micro++ {
+			roundTrip(float64(micro)/1e6, lon)
+		}
+	}
+
+	// Sweep every micro-degree across the Greenwich meridian and the
+	// antimeridian, in both hemispheres.
+	for _, lat := range []float64{-0.5, 0, 0.5} {
+		for micro := int64(-1500000); micro <= 1500000; micro++ {
+			roundTrip(lat, float64(micro)/1e6)
+		}
+		for micro := int64(-180000000); micro <= -178500000; micro++ {
+			roundTrip(lat, float64(micro)/1e6)
+		}
+		for micro := int64(178500000); micro < 180000000; micro++ {
+			roundTrip(lat, float64(micro)/1e6)
+		}
+	}
+}
+
+func TestEncoder_LeadingZeros(t *testing.T) {
+	lat, lon := 43.000005, -1.000042
+
+	p := geopoint.Encode(lat, lon)
+	pLat, pLon, err := geopoint.FromString(p.Code())
+	if err != nil {
+		t.Fatalf("error should not be raised, got %v", err)
+	}
+	if pLat != lat {
+		t.Fatalf("invalid latitude, expected %v, got %v", lat, pLat)
+	}
+	if pLon != lon {
+		t.Fatalf("invalid longitude, expected %v, got %v", lon, pLon)
+	}
+}
+
+func TestEncoder_Fractions(t *testing.T) {
+	// Every fractional micro-degree value must survive a round trip.
+	for _, degree := range []int64{-90, -44, -1, 0, 43, 89} {
+		for fraction := int64(0); fraction < 1000000; fraction++ {
+			lat := float64(degree*1000000+fraction) / 1e6
+			pLat, _, _ := geopoint.Decode(geopoint.Encode(lat, 0))
+			if pLat != lat {
+				t.Fatalf("invalid latitude, expected %v, got %v", lat, pLat)
+			}
+		}
+	}
+	for _, degree := range []int64{-180, -1, 0, 1, 179} {
+		for fraction := int64(0); fraction < 1000000; fraction++ {
+			lon := float64(degree*1000000+fraction) / 1e6
+			_, pLon, _ := geopoint.Decode(geopoint.Encode(0, lon))
+			if pLon != lon {
+				t.Fatalf("invalid longitude, expected %v, got %v", lon, pLon)
+			}
+		}
+	}
+}
+
+func TestEncoder_Precision(t *testing.T) {
+	const tolerance = 0.5e-6 + 1e-12
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000000; i++ {
+		lat := r.Float64()*180 - 90
+		lon := r.Float64()*360 - 180
+
+		pLat, pLon, err := geopoint.Decode(geopoint.Encode(lat, lon))
+		if err != nil {
+			t.Fatalf("error should not be raised, got %v", err)
+		}
+		if d := math.Abs(pLat - lat); d > tolerance {
+			t.Fatalf("latitude %v decoded as %v, error %v", lat, pLat, d)
+		}
+		// 180 and -180 are the same meridian
+		if d := math.Abs(math.Remainder(pLon-lon, 360)); d > tolerance {
+			t.Fatalf("longitude %v decoded as %v, error %v", lon, pLon, d)
+		}
+	}
+}
+
+func TestEncoder_Order(t *testing.T) {
+	// With a fixed longitude, encoded values must follow latitude ordering.
+	for _, lon := range []float64{-73.870384, -0.5, 0, 1.442917} {
+		prev := geopoint.Encode(-2, lon)
+		for micro := int64(-1999999); micro <= 2000000; micro++ {
+			p := geopoint.Encode(float64(micro)/1e6, lon)
+			if p <= prev {
+				t.Fatalf("order is not preserved at latitude %v", float64(micro)/1e6)
+			}
+			prev = p
+		}
+	}
+}
+
+func TestCodec_Allocations(t *testing.T) {
+	lat, lon := 43.603574, 1.442917
+
+	if n := testing.AllocsPerRun(100, func() { _ = geopoint.Encode(lat, lon) }); n != 0 {
+		t.Fatalf("Encode should not allocate, got %v allocations", n)
+	}
+	if n := testing.AllocsPerRun(100, func() { _, _, _ = geopoint.Decode(geopoint.Value(75071809151126838)) }); n != 0 {
+		t.Fatalf("Decode should not allocate, got %v allocations", n)
+	}
+}
+
+// -----------------------------------------------------------------------------
+
+var benchPoints = []struct {
+	lat float64
+	lon float64
+}{
+	{lat: 43.603574, lon: 1.442917},
+	{lat: 48.858373, lon: 2.292292},
+	{lat: 45.558196, lon: -73.870384},
+	{lat: -34.615662, lon: -58.503337},
+}
+
 func BenchmarkDecoder_Decode(b *testing.B) {
-	p := geopoint.Value(31659983379082793)
+	points := make([]geopoint.Value, len(benchPoints))
+	for i, p := range benchPoints {
+		points[i] = geopoint.Encode(p.lat, p.lon)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, _, _ = geopoint.Decode(p)
+		_, _, _ = geopoint.Decode(points[i%len(points)])
 	}
 }
 
 func BenchmarkCodec_Encode(b *testing.B) {
-	lat, lon := 43.603574, 1.442917
+	b.ReportAllocs()
 	for i := 0; i < b.N; i++ {
-		_ = geopoint.Encode(lat, lon)
+		p := benchPoints[i%len(benchPoints)]
+		_ = geopoint.Encode(p.lat, p.lon)
 	}
 }
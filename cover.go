@@ -0,0 +1,445 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package geopoint
+
+import (
+	"container/heap"
+	"math"
+	"math/bits"
+)
+
+// Range is an inclusive range of point values
+type Range struct {
+	Min, Max Value
+}
+
+// Contains returns true if the given point is in the range
+func (r Range) Contains(p Value) bool {
+	return r.Min <= p && p <= r.Max
+}
+
+// CoverRect returns at most maxRanges sorted and disjoint ranges containing
+// every point of the given rectangle. Ranges cover points without header
+// flags, as produced by Encode. Points outside the rectangle may be included,
+// more ranges give less false positives. A maxRanges below 1 is handled as 1,
+// a non-empty rectangle is always covered by at least one range.
+//
+// Boxes are recursively split along the most significant differing bit of
+// their bounds (integer latitude, integer longitude, then the interleaved
+// fractions, using LITMAX and BIGMIN as split bounds), the box wasting the most
+// values first.
+func CoverRect(rect Rect, maxRanges int) []Range {
+	return plainRanges(cover(rectBoxes(rect), maxRanges, nil, false))
+}
+
+// -----------------------------------------------------------------------------
+
+// coverBox is a box of micro-degrees, rebased on the south pole and the
+// antimeridian, bounds included.
+type coverBox struct {
+	minLat, minLon int64
+	maxLat, maxLon int64
+}
+
+// rectBoxes converts a rectangle to boxes, a rectangle crossing the
+// antimeridian is split in two boxes.
+func rectBoxes(rect Rect) []coverBox {
+	if math.IsNaN(rect.MinLon) || math.IsNaN(rect.MaxLon) || rect.IsEmpty() {
+		return nil
+	}
+
+	minLat, maxLat := microDegreesRange(math.Max(rect.MinLat, -90), math.Min(rect.MaxLat, 90))
+	if minLat > maxLat {
+		return nil
+	}
+	minLat += 90 * microDegrees
+	maxLat += 90 * microDegrees
+
+	// Longitude 180 is encoded as -180
+	const lonMax = 360*microDegrees - 1
+	minLon, maxLon := microDegreesRange(math.Max(rect.MinLon, -180), math.Min(rect.MaxLon, 180))
+	minLon += 180 * microDegrees
+	maxLon += 180 * microDegrees
+	if maxLon > lonMax {
+		maxLon = lonMax
+	}
+
+	switch {
+	case !rect.crossesAntimeridian() && minLon <= maxLon:
+		return []coverBox{newCoverBox(minLat, minLon, maxLat, maxLon)}
+	case !rect.crossesAntimeridian():
+		return nil
+	}
+
+	// Both sides are split by rows so that box values never overlap
+	boxes := make([]coverBox, 0, 2*(maxLat/microDegrees-minLat/microDegrees+1))
+	for row := minLat / microDegrees; row <= maxLat/microDegrees; row++ {
+		lo, hi := row*microDegrees, row*microDegrees+microDegrees-1
+		if lo < minLat {
+			lo = minLat
+		}
+		if hi > maxLat {
+			hi = maxLat
+		}
+		boxes = append(boxes, newCoverBox(lo, 0, hi, maxLon), newCoverBox(lo, minLon, hi, lonMax))
+	}
+
+	return boxes
+}
+
+// microDegreesRange returns the micro-degrees whose decoded value is in the
+// given range.
+func microDegreesRange(min, max float64) (int64, int64) {
+	lo := int64(math.Ceil(min * microDegrees))
+	for fromMicroDegrees(lo-1) >= min {
+		lo--
+	}
+	for fromMicroDegrees(lo) < min {
+		lo++
+	}
+
+	hi := int64(math.Floor(max * microDegrees))
+	for fromMicroDegrees(hi+1) <= max {
+		hi++
+	}
+	for fromMicroDegrees(hi) > max {
+		hi--
+	}
+
+	return lo, hi
+}
+
+// newCoverBox returns a box with the given bounds
+func newCoverBox(minLat, minLon, maxLat, maxLon int64) coverBox {
+	return coverBox{minLat: minLat, minLon: minLon, maxLat: maxLat, maxLon: maxLon}
+}
+
+// area returns the number of points of the box
+func (b coverBox) area() int64 {
+	return (b.maxLat - b.minLat + 1) * (b.maxLon - b.minLon + 1)
+}
+
+// rect returns the box in degrees
+func (b coverBox) rect() Rect {
+	return Rect{
+		MinLat: fromMicroDegrees(b.minLat - 90*microDegrees),
+		MinLon: fromMicroDegrees(b.minLon - 180*microDegrees),
+		MaxLat: fromMicroDegrees(b.maxLat - 90*microDegrees),
+		MaxLon: fromMicroDegrees(b.maxLon - 180*microDegrees),
+	}
+}
+
+// keys returns the values of the box corners, every value of the box is
+// between them.
+func (b coverBox) keys() (uint64, uint64) {
+	return coverKey(b.minLat, b.minLon), coverKey(b.maxLat, b.maxLon)
+}
+
+// split splits the box in two at the most significant differing bit of its
+// corner values, the first box ends at LITMAX and the second starts at BIGMIN.
+func (b coverBox) split() (coverBox, coverBox) {
+	switch {
+	case b.minLat/microDegrees != b.maxLat/microDegrees:
+		s := splitValue(b.minLat/microDegrees, b.maxLat/microDegrees) * microDegrees
+		return newCoverBox(b.minLat, b.minLon, s-1, b.maxLon), newCoverBox(s, b.minLon, b.maxLat, b.maxLon)
+	case b.minLon/microDegrees != b.maxLon/microDegrees:
+		s := splitValue(b.minLon/microDegrees, b.maxLon/microDegrees) * microDegrees
+		return newCoverBox(b.minLat, b.minLon, b.maxLat, s-1), newCoverBox(b.minLat, s, b.maxLat, b.maxLon)
+	}
+
+	// Same degree cell, split the interleaved fractions
+	min, max := b.keys()
+	bit := uint(63 - bits.LeadingZeros64(min^max))
+	if bit%2 == 0 {
+		s := b.maxLat - b.maxLat%microDegrees + splitValue(b.minLat%microDegrees, b.maxLat%microDegrees)
+		return newCoverBox(b.minLat, b.minLon, s-1, b.maxLon), newCoverBox(s, b.minLon, b.maxLat, b.maxLon)
+	}
+	s := b.maxLon - b.maxLon%microDegrees + splitValue(b.minLon%microDegrees, b.maxLon%microDegrees)
+	return newCoverBox(b.minLat, b.minLon, b.maxLat, s-1), newCoverBox(b.minLat, s, b.maxLat, b.maxLon)
+}
+
+// splitValue returns the highest value of the form prefix|1|0...0 in ]a; b]
+func splitValue(a, b int64) int64 {
+	shift := uint(63 - bits.LeadingZeros64(uint64(a^b)))
+	return b >> shift << shift
+}
+
+// coverKey returns the value of the given rebased micro-degrees
+func coverKey(lat, lon int64) uint64 {
+	return uint64(lat/microDegrees)<<49 | uint64(lon/microDegrees)<<40 |
+		interleave(uint32(lat%microDegrees), uint32(lon%microDegrees))
+}
+
+// countValues returns the number of valid values in [min; max]
+func countValues(min, max uint64) int64 {
+	return countValuesBelow(max) - countValuesBelow(min) + 1
+}
+
+// countValuesBelow returns the number of valid values lower than the given one
+func countValuesBelow(k uint64) int64 {
+	highLat := int64((k >> 49) & 0xFF)
+	highLon := int64((k >> 40) & 0x1FF)
+
+	// Latitude 90 has no fraction
+	count := highLat * 360 * microDegrees * microDegrees
+	cell, latLimit := int64(microDegrees*microDegrees), int64(microDegrees)
+	if highLat == 180 {
+		cell, latLimit = microDegrees, 1
+	}
+	if highLon >= 360 {
+		return count + 360*cell
+	}
+	count += highLon * cell
+
+	// Count valid fractions of every aligned block before the value
+	z := k & 0xFFFFFFFFFF
+	for i := uint(0); i < 40; i++ {
+		if z&(1<<i) == 0 {
+			continue
+		}
+		lat, lon := deinterleave(z >> (i + 1) << (i + 1))
+		count += overlap(int64(lat), 1<<((i+1)/2), latLimit) * overlap(int64(lon), 1<<(i/2), microDegrees)
+	}
+
+	return count
+}
+
+// overlap returns the size of [start; start+size[ inside [0; limit[
+func overlap(start, size, limit int64) int64 {
+	if start >= limit {
+		return 0
+	}
+	if start+size > limit {
+		return limit - start
+	}
+	return size
+}
+
+// coverRegion tells if a box intersects the covered region, and if it is
+// contained by the region.
+type coverRegion func(b coverBox) (intersects, contained bool)
+
+// coverNode is a box of the cover, nodes are linked in value order
+type coverNode struct {
+	coverBox
+	min, max   uint64
+	prev, next *coverNode
+	// Number of valid values in the box range, but outside the region
+	waste     int64
+	contained bool
+}
+
+// coverRange is a range of the cover, contained ranges only have values of the
+// region.
+type coverRange struct {
+	Range
+	contained bool
+}
+
+// cover refines the given boxes until no box wastes values or the range count
+// reaches maxRanges. Boxes must be sorted and their values must not overlap,
+// boxes outside the region are dropped, a nil region contains every box. When
+// separate is set, contained and partially covered boxes never share a range.
+func cover(boxes []coverBox, maxRanges int, region coverRegion, separate bool) []coverRange {
+	// At least one range is required to cover anything
+	if maxRanges < 1 {
+		maxRanges = 1
+	}
+
+	// Link region boxes
+	var (
+		h           coverHeap
+		first, last *coverNode
+	)
+	for _, b := range boxes {
+		n := newCoverNode(b, region)
+		if n == nil {
+			continue
+		}
+		if last == nil {
+			first = n
+		} else {
+			last.next, n.prev = n, last
+		}
+		last = n
+		h = append(h, n)
+	}
+	if first == nil {
+		return nil
+	}
+	heap.Init(&h)
+
+	count := 0
+	for n := first; n != nil; n = n.next {
+		count += n.startsRange(separate)
+	}
+
+	// Bound the work spent on splits that do not add ranges
+	for i := 0; i < 16*maxRanges+64 && len(h) > 0 && h[0].waste > 0; i++ {
+		n := h[0]
+		b1, b2 := n.split()
+		children := make([]*coverNode, 0, 2)
+		for _, b := range [2]coverBox{b1, b2} {
+			if c := newCoverNode(b, region); c != nil {
+				children = append(children, c)
+			}
+		}
+
+		// Range count once the node is replaced by its children
+		delta := -n.startsRange(separate)
+		prev := n.prev
+		for _, c := range children {
+			c.prev = prev
+			delta += c.startsRange(separate)
+			prev = c
+		}
+		if next := n.next; next != nil {
+			delta -= next.startsRange(separate)
+			next.prev = prev
+			delta += next.startsRange(separate)
+			next.prev = n
+		}
+		if delta > 0 && count+delta > maxRanges {
+			break
+		}
+		count += delta
+
+		// Replace the node
+		heap.Pop(&h)
+		prev = n.prev
+		for _, c := range children {
+			if prev == nil {
+				first = c
+			} else {
+				prev.next = c
+			}
+			prev = c
+			heap.Push(&h, c)
+		}
+		if prev == nil {
+			first = n.next
+		} else {
+			prev.next = n.next
+		}
+		if n.next != nil {
+			n.next.prev = prev
+		}
+		if first == nil {
+			return nil
+		}
+	}
+
+	// Build ranges from linked boxes
+	ranges := make([]coverRange, 0, count)
+	for n := first; n != nil; n = n.next {
+		if n.startsRange(separate) == 1 {
+			ranges = append(ranges, coverRange{Range: Range{Min: Value(n.min), Max: Value(n.max)}, contained: n.contained})
+		} else {
+			last := &ranges[len(ranges)-1]
+			last.Max = Value(n.max)
+			last.contained = last.contained && n.contained
+		}
+	}
+
+	return mergeRanges(ranges, maxRanges)
+}
+
+// newCoverNode returns a node for the given box, or nil if the box is outside
+// the region.
+func newCoverNode(b coverBox, region coverRegion) *coverNode {
+	intersects, contained := true, true
+	if region != nil {
+		intersects, contained = region(b)
+	}
+	if !intersects {
+		return nil
+	}
+
+	n := &coverNode{coverBox: b, contained: contained}
+	n.min, n.max = b.keys()
+	if n.min == n.max {
+		return n
+	}
+
+	// Half of a partially covered box is expected to be outside the region
+	n.waste = countValues(n.min, n.max) - b.area()
+	if !contained {
+		n.waste += b.area() / 2
+	}
+
+	return n
+}
+
+// startsRange returns 1 if valid values are left between the node and the
+// previous one, or if separated nodes are of different kinds, 0 otherwise.
+func (n *coverNode) startsRange(separate bool) int {
+	switch {
+	case n.prev == nil:
+		return 1
+	case separate && n.contained != n.prev.contained:
+		return 1
+	case countValuesBelow(n.min)-countValuesBelow(n.prev.max) > 1:
+		return 1
+	}
+	return 0
+}
+
+// mergeRanges merges the ranges separated by the fewest valid values until
+// there are at most maxRanges ranges.
+func mergeRanges(ranges []coverRange, maxRanges int) []coverRange {
+	for len(ranges) > maxRanges {
+		best, gap := 0, int64(math.MaxInt64)
+		for i := 0; i+1 < len(ranges); i++ {
+			if g := countValuesBelow(uint64(ranges[i+1].Min)) - countValuesBelow(uint64(ranges[i].Max)); g < gap {
+				best, gap = i, g
+			}
+		}
+		ranges[best].Max = ranges[best+1].Max
+		ranges[best].contained = ranges[best].contained && ranges[best+1].contained
+		ranges = append(ranges[:best+1], ranges[best+2:]...)
+	}
+
+	return ranges
+}
+
+// plainRanges returns the ranges of the cover
+func plainRanges(cover []coverRange) []Range {
+	if len(cover) == 0 {
+		return nil
+	}
+
+	ranges := make([]Range, len(cover))
+	for i, r := range cover {
+		ranges[i] = r.Range
+	}
+	return ranges
+}
+
+// coverHeap is a max-heap of nodes ordered by waste
+type coverHeap []*coverNode
+
+func (h coverHeap) Len() int            { return len(h) }
+func (h coverHeap) Less(i, j int) bool  { return h[i].waste > h[j].waste }
+func (h coverHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *coverHeap) Push(x interface{}) { *h = append(*h, x.(*coverNode)) }
+func (h *coverHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
@@ -0,0 +1,176 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package geopoint_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"go.zenithar.org/geopoint"
+)
+
+func TestCoverRect(t *testing.T) {
+	tcl := []struct {
+		name     string
+		rect     geopoint.Rect
+		expected []geopoint.Range
+	}{
+		{
+			name: "Degree cell",
+			rect: geopoint.Rect{MinLat: 43, MinLon: 1, MaxLat: 43.999999, MaxLon: 1.999999},
+			expected: []geopoint.Range{
+				{Min: geopoint.Encode(43, 1), Max: geopoint.Encode(43.999999, 1.999999)},
+			},
+		},
+		{
+			name: "Every longitude",
+			rect: geopoint.Rect{MinLat: 10, MinLon: -180, MaxLat: 19.999999, MaxLon: 180},
+			expected: []geopoint.Range{
+				{Min: geopoint.Encode(10, -180), Max: geopoint.Encode(19.999999, 179.999999)},
+			},
+		},
+		{
+			name: "Aligned block",
+			rect: geopoint.Rect{MinLat: 43, MinLon: 1, MaxLat: 43.000015, MaxLon: 1.000015},
+			expected: []geopoint.Range{
+				{Min: geopoint.Encode(43, 1), Max: geopoint.Encode(43.000015, 1.000015)},
+			},
+		},
+		{
+			name: "Point",
+			rect: geopoint.Rect{MinLat: 43.603574, MinLon: 1.442917, MaxLat: 43.603574, MaxLon: 1.442917},
+			expected: []geopoint.Range{
+				{Min: geopoint.Encode(43.603574, 1.442917), Max: geopoint.Encode(43.603574, 1.442917)},
+			},
+		},
+		{
+			name: "Latitude split",
+			rect: geopoint.Rect{MinLat: 0, MinLon: 0, MaxLat: 0.999999, MaxLon: 0.000001},
+			expected: []geopoint.Range{
+				{Min: geopoint.Encode(0, 0), Max: geopoint.Encode(0.524287, 0.000001)},
+				{Min: geopoint.Encode(0.524288, 0), Max: geopoint.Encode(0.999999, 0.000001)},
+			},
+		},
+		{name: "Empty", rect: geopoint.EmptyRect()},
+	}
+
+	for _, tc := range tcl {
+		t.Run(tc.name, func(t *testing.T) {
+			out := geopoint.CoverRect(tc.rect, 2)
+			if len(out) != len(tc.expected) {
+				t.Fatalf("Invalid result: expected %v but got %v", tc.expected, out)
+			}
+			for i := range out {
+				if out[i] != tc.expected[i] {
+					t.Fatalf("Invalid result: expected %v but got %v", tc.expected, out)
+				}
+			}
+		})
+	}
+}
+
+func TestCoverRect_MaxRanges(t *testing.T) {
+	rect := geopoint.Rect{MinLat: 0, MinLon: 0, MaxLat: 0.999999, MaxLon: 0.000001}
+	expected := geopoint.Range{Min: geopoint.Encode(0, 0), Max: geopoint.Encode(0.999999, 0.000001)}
+
+	// Below 1, a single range is returned
+	for _, maxRanges := range []int{1, 0, -1} {
+		out := geopoint.CoverRect(rect, maxRanges)
+		if len(out) != 1 || out[0] != expected {
+			t.Fatalf("Invalid result for %d ranges: expected [%v] but got %v", maxRanges, expected, out)
+		}
+	}
+}
+
+func TestCoverRect_BruteForce(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	points := make([]geopoint.Value, 20000)
+	for i := range points {
+		points[i] = geopoint.Encode(r.Float64()*180-90, r.Float64()*360-180)
+	}
+
+	for i := 0; i < 200; i++ {
+		lat, lon := r.Float64()*180-90, r.Float64()*360-180
+		rect := geopoint.Rect{
+			MinLat: lat, MinLon: lon,
+			MaxLat: lat + r.Float64()*30, MaxLon: lon + r.Float64()*60,
+		}
+		if rect.MaxLon > 180 {
+			rect.MaxLon -= 360
+		}
+
+		for _, maxRanges := range []int{1, 4, 32} {
+			ranges := geopoint.CoverRect(rect, maxRanges)
+			if len(ranges) > maxRanges || len(ranges) == 0 {
+				t.Fatalf("Invalid result: expected at most %d ranges, got %d", maxRanges, len(ranges))
+			}
+			for j := 1; j < len(ranges); j++ {
+				if ranges[j].Min <= ranges[j-1].Max {
+					t.Fatalf("Invalid result: ranges should be sorted and disjoint, got %v", ranges)
+				}
+			}
+
+			for _, p := range points {
+				if !rect.Contains(p) {
+					continue
+				}
+				found := false
+				for _, rg := range ranges {
+					found = found || rg.Contains(p)
+				}
+				if !found {
+					t.Fatalf("Invalid result: %f should be covered by %v for %v", p, ranges, rect)
+				}
+			}
+		}
+	}
+}
+
+func TestCoverRect_FalsePositives(t *testing.T) {
+	rect := geopoint.Rect{MinLat: 43.5, MinLon: 1.3, MaxLat: 43.7, MaxLon: 1.6}
+
+	r := rand.New(rand.NewSource(1))
+	previous := 1.0
+	for _, maxRanges := range []int{1, 8, 64} {
+		ranges := geopoint.CoverRect(rect, maxRanges)
+
+		// Count points covered by ranges, but outside the rectangle
+		covered, outside := 0, 0
+		for i := 0; i < 20000; i++ {
+			p := geopoint.Encode(43+r.Float64(), 1+r.Float64())
+			in := false
+			for _, rg := range ranges {
+				in = in || rg.Contains(p)
+			}
+			if in {
+				covered++
+				if !rect.Contains(p) {
+					outside++
+				}
+			}
+		}
+
+		ratio := float64(outside) / float64(covered)
+		if ratio > previous {
+			t.Fatalf("Invalid result: false positives should decrease with %d ranges, got %f after %f", maxRanges, ratio, previous)
+		}
+		previous = ratio
+	}
+	if previous > 0.1 {
+		t.Fatalf("Invalid result: too many false positives with 64 ranges, got %f", previous)
+	}
+}
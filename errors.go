@@ -16,11 +16,66 @@
 
 package geopoint
 
-import "errors"
+import (
+	"errors"
+	"strconv"
+)
 
 var (
-	// ErrInvalidGeoPointHash is raised when the given hash is syntaxically invalid
+	// ErrInvalidGeoPointHash is raised when the given hash is syntactically invalid
 	ErrInvalidGeoPointHash = errors.New("geopoint: invalid geopoint hash value")
 	// ErrInvalidGeoPointValue is raised when the given hash does not contain a valid value
 	ErrInvalidGeoPointValue = errors.New("geopoint: invalid geopoint value")
+	// ErrEmptyGeoPoint is raised when trying to decode the empty point
+	ErrEmptyGeoPoint = errors.New("geopoint: empty geopoint")
+	// ErrChecksumMismatch is raised when the check characters of the given code do not match
+	ErrChecksumMismatch = errors.New("geopoint: geopoint checksum mismatch")
+	// ErrInvalidGeohash is raised when the given geohash is syntactically invalid
+	ErrInvalidGeohash = errors.New("geopoint: invalid geohash value")
+	// ErrInvalidMaidenhead is raised when the given Maidenhead locator is syntactically invalid,
+	// or when the requested count of pairs is not supported
+	ErrInvalidMaidenhead = errors.New("geopoint: invalid maidenhead locator")
+	// ErrInvalidZoom is raised when the given zoom level is not in [0, MaxZoom]
+	ErrInvalidZoom = errors.New("geopoint: invalid tile zoom level")
+	// ErrInvalidTile is raised when the given tile coordinates do not exist at the zoom level
+	ErrInvalidTile = errors.New("geopoint: invalid tile coordinates")
+	// ErrInvalidQuadKey is raised when the given quadkey is syntactically invalid
+	ErrInvalidQuadKey = errors.New("geopoint: invalid quadkey")
+	// ErrUnsupportedVersion is raised when the given point uses an unknown encoding version
+	ErrUnsupportedVersion = errors.New("geopoint: unsupported geopoint encoding version")
 )
+
+// ErrLatitudeOutOfRange is raised when the given latitude is not in [-90; 90]
+type ErrLatitudeOutOfRange float64
+
+func (e ErrLatitudeOutOfRange) Error() string {
+	return "geopoint: latitude out of range " + strconv.FormatFloat(float64(e), 'f', -1, 64)
+}
+
+// ErrLongitudeOutOfRange is raised when the given longitude is not in [-180; 180]
+type ErrLongitudeOutOfRange float64
+
+func (e ErrLongitudeOutOfRange) Error() string {
+	return "geopoint: longitude out of range " + strconv.FormatFloat(float64(e), 'f', -1, 64)
+}
+
+// ErrNotFinite is raised when the given coordinate is NaN or infinite
+type ErrNotFinite float64
+
+func (e ErrNotFinite) Error() string {
+	return "geopoint: coordinate is not finite " + strconv.FormatFloat(float64(e), 'f', -1, 64)
+}
+
+// ErrSyntax is raised when a coordinate string could not be parsed
+type ErrSyntax struct {
+	// Input is the parsed string
+	Input string
+	// Pos is the index, in characters, of the failing part of the input
+	Pos int
+	// Reason describes the failure
+	Reason string
+}
+
+func (e ErrSyntax) Error() string {
+	return "geopoint: invalid coordinates " + strconv.Quote(e.Input) + " at position " + strconv.Itoa(e.Pos) + ": " + e.Reason
+}
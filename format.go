@@ -0,0 +1,162 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package geopoint
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// String returns the point hexadecimal code
+func (p Value) String() string {
+	return p.Code()
+}
+
+// Format implements fmt.Formatter, supported verbs are:
+//
+//	%s, %v  hexadecimal code
+//	%q      quoted hexadecimal code
+//	%d      raw integer
+//	%x, %X  raw integer in hexadecimal, 0x prefixed with '#'
+//	%f, %F  "lat,lon", 6 decimals unless a precision is given (%.3f)
+//	%+v     header and decoded coordinates
+//	%#v     Go syntax
+//
+// The empty point has no code nor coordinates, it is printed as an empty
+// string by %s, %q, %v and %f.
+func (p Value) Format(f fmt.State, verb rune) {
+	var buf [128]byte
+	b := buf[:0]
+
+	switch verb {
+	case 's':
+		b = p.appendCodeOrEmpty(b)
+	case 'v':
+		switch {
+		case f.Flag('+'):
+			b = p.appendDebug(b)
+		case f.Flag('#'):
+			b = append(b, "geopoint.Value(0x"...)
+			b = strconv.AppendUint(b, uint64(p), 16)
+			b = append(b, ')')
+		default:
+			b = p.appendCodeOrEmpty(b)
+		}
+	case 'q':
+		b = append(b, '"')
+		b = p.appendCodeOrEmpty(b)
+		b = append(b, '"')
+	case 'd':
+		b = strconv.AppendUint(b, uint64(p), 10)
+	case 'x', 'X':
+		if f.Flag('#') {
+			b = append(b, '0', byte(verb))
+		}
+		start := len(b)
+		b = strconv.AppendUint(b, uint64(p), 16)
+		if verb == 'X' {
+			for i := start; i < len(b); i++ {
+				if b[i] >= 'a' {
+					b[i] -= 'a' - 'A'
+				}
+			}
+		}
+	case 'f', 'F':
+		prec, ok := f.Precision()
+		if !ok {
+			prec = 6
+		}
+		b = p.appendCoordinates(b, prec)
+	default:
+		b = append(b, "%!"...)
+		b = append(b, string(verb)...)
+		b = append(b, "(geopoint.Value="...)
+		b = strconv.AppendUint(b, uint64(p), 10)
+		b = append(b, ')')
+	}
+
+	writePadding(f, len(b), false)
+	f.Write(b)
+	writePadding(f, len(b), true)
+}
+
+// -----------------------------------------------------------------------------
+
+// appendCodeOrEmpty appends the point code, the empty point has no code
+func (p Value) appendCodeOrEmpty(b []byte) []byte {
+	if p == Empty {
+		return b
+	}
+	return p.appendCode(b)
+}
+
+// appendCoordinates appends "lat,lon" with the given number of decimals
+func (p Value) appendCoordinates(b []byte, prec int) []byte {
+	lat, lon, err := Decode(p)
+	if err != nil {
+		return b
+	}
+
+	b = strconv.AppendFloat(b, lat, 'f', prec, 64)
+	b = append(b, ',')
+	return strconv.AppendFloat(b, lon, 'f', prec, 64)
+}
+
+// appendDebug appends a description of the header and coordinates, like
+// {Version:0 Flags:PT- Level:14 Lat:43.603574 Lon:1.442917 Code:10AB5:69A51:A}
+func (p Value) appendDebug(b []byte) []byte {
+	if p == Empty {
+		return append(b, "{Empty}"...)
+	}
+
+	flags := p.Flags()
+	b = append(b, "{Version:"...)
+	b = strconv.AppendUint(b, uint64(p.Version()), 10)
+	b = append(b, " Flags:"...)
+	for i, c := range "PTA" {
+		if flags.Has(Flags(1) << uint(i)) {
+			b = append(b, byte(c))
+		} else {
+			b = append(b, '-')
+		}
+	}
+	b = append(b, " Level:"...)
+	b = strconv.AppendUint(b, uint64(p.Precision()), 10)
+
+	lat, lon, _ := Decode(p)
+	b = append(b, " Lat:"...)
+	b = strconv.AppendFloat(b, lat, 'f', -1, 64)
+	b = append(b, " Lon:"...)
+	b = strconv.AppendFloat(b, lon, 'f', -1, 64)
+	b = append(b, " Code:"...)
+	b = p.appendCode(b)
+
+	return append(b, '}')
+}
+
+// writePadding writes spaces before or after a content of the given length to
+// reach the requested width.
+func writePadding(f fmt.State, n int, trailing bool) {
+	width, ok := f.Width()
+	if !ok || f.Flag('-') != trailing {
+		return
+	}
+	for ; n < width; n++ {
+		io.WriteString(f, " ")
+	}
+}
@@ -0,0 +1,90 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package geopoint_test
+
+import (
+	"fmt"
+	"testing"
+
+	"go.zenithar.org/geopoint"
+)
+
+func TestValue_Format(t *testing.T) {
+	p := geopoint.Encode(43.603574, 1.442917)
+	truncated := p.Truncate(14)
+
+	tcl := []struct {
+		name     string
+		format   string
+		value    geopoint.Value
+		expected string
+	}{
+		{name: "String", format: "%s", value: p, expected: "10AB5:69A51:94D36"},
+		{name: "Value", format: "%v", value: p, expected: "10AB5:69A51:94D36"},
+		{name: "Width", format: "[%20s]", value: p, expected: "[   10AB5:69A51:94D36]"},
+		{name: "Left", format: "[%-20v]", value: p, expected: "[10AB5:69A51:94D36   ]"},
+		{name: "Quoted", format: "%q", value: p, expected: `"10AB5:69A51:94D36"`},
+		{name: "Integer", format: "%d", value: p, expected: "75071809151126838"},
+		{name: "Hexadecimal", format: "%x", value: p, expected: "10ab569a5194d36"},
+		{name: "Upper hexadecimal", format: "%#X", value: p, expected: "0X10AB569A5194D36"},
+		{name: "Coordinates", format: "%f", value: p, expected: "43.603574,1.442917"},
+		{name: "Coordinates precision", format: "%.3f", value: p, expected: "43.604,1.443"},
+		{name: "Negative coordinates", format: "%.2F", value: geopoint.Encode(-22.9068, -43.1729), expected: "-22.91,-43.17"},
+		{name: "Go syntax", format: "%#v", value: p, expected: "geopoint.Value(0x10ab569a5194d36)"},
+		{name: "Debug", format: "%+v", value: p, expected: "{Version:0 Flags:--- Level:20 Lat:43.603574 Lon:1.442917 Code:10AB5:69A51:94D36}"},
+		{name: "Debug truncated", format: "%+v", value: truncated, expected: "{Version:0 Flags:PT- Level:14 Lat:43.60352 Lon:1.44288 Code:" + truncated.Code() + "}"},
+		{name: "Debug anonymized", format: "%+v", value: p.WithFlags(geopoint.FlagAnonymized), expected: "{Version:0 Flags:--A Level:20 Lat:43.603574 Lon:1.442917 Code:90AB5:69A51:94D36}"},
+		{name: "Empty string", format: "[%s]", value: geopoint.Empty, expected: "[]"},
+		{name: "Empty coordinates", format: "[%f]", value: geopoint.Empty, expected: "[]"},
+		{name: "Empty debug", format: "%+v", value: geopoint.Empty, expected: "{Empty}"},
+		{name: "Empty integer", format: "%d", value: geopoint.Empty, expected: "18446744073709551615"},
+		{name: "Unsupported verb", format: "%t", value: p, expected: "%!t(geopoint.Value=75071809151126838)"},
+	}
+
+	for _, tc := range tcl {
+		t.Run(tc.name, func(t *testing.T) {
+			if out := fmt.Sprintf(tc.format, tc.value); out != tc.expected {
+				t.Fatalf("Invalid result: expected %s but got %s", tc.expected, out)
+			}
+		})
+	}
+}
+
+func TestValue_String(t *testing.T) {
+	for _, p := range randomPoints(1000) {
+		if p.String() != p.Code() {
+			t.Fatalf("Invalid result: expected %s but got %s", p.Code(), p.String())
+		}
+	}
+}
+
+func TestValue_Format_Allocs(t *testing.T) {
+	p := geopoint.Encode(43.603574, 1.442917)
+
+	// Printing the code includes its interface conversion
+	code := testing.AllocsPerRun(100, func() { fmt.Fprintf(discard{}, "%s", p.Code()) })
+	for _, format := range []string{"%s", "%v", "%d", "%x", "%.3f", "%+v"} {
+		allocs := testing.AllocsPerRun(100, func() { fmt.Fprintf(discard{}, format, p) })
+		if allocs > code {
+			t.Fatalf("Invalid result: %s allocates %v times, Code allocates %v times", format, allocs, code)
+		}
+	}
+}
+
+type discard struct{}
+
+func (discard) Write(b []byte) (int, error) { return len(b), nil }
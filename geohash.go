@@ -0,0 +1,131 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package geopoint
+
+import (
+	"math"
+	"strings"
+)
+
+// Geohash interoperability.
+//
+// A geohash splits both axis in 2^n parts, interleaving longitude and latitude
+// bits (longitude first) and printing them 5 bits per character. The cell size
+// depends on the hash length:
+//
+//	chars  lat bits  lon bits  cell size (lat x lon)
+//	    1         2         3  45°    x 45°
+//	    4        10        10  0.18°  x 0.35°
+//	    6        15        15  5.5e-3 x 1.1e-2  (~610m x 1.2km)
+//	    8        20        20  1.7e-4 x 3.4e-4  (~19m x 38m)
+//	   10        25        25  5.4e-6 x 1.1e-5  (~60cm x 1.2m)
+//	   12        30        30  1.7e-7 x 3.4e-7  (~2cm x 4cm)
+//
+// Value to geohash: the point is quantized to the geohash cell containing it,
+// up to 12 characters are supported. With 12 characters, the cell is smaller
+// than a micro-degree and the conversion is lossless.
+//
+// Geohash to Value: the center of the geohash cell is encoded with the
+// micro-degree precision. Nothing is lost for hashes up to 10 characters, but
+// the point stands for the whole cell and the hash length is not preserved.
+// Longer hashes are rounded to the nearest micro-degree.
+const (
+	geohashAlphabet  = "0123456789bcdefghjkmnpqrstuvwxyz"
+	geohashMaxLength = 12
+)
+
+var (
+	geohashDecoding = decodingTable(geohashAlphabet)
+)
+
+func init() {
+	// Accept uppercase hashes
+	for _, c := range geohashAlphabet {
+		geohashDecoding[strings.ToUpper(string(c))[0]] = geohashDecoding[c]
+	}
+}
+
+// ToGeohash returns the geohash of the given point with the given number of
+// characters (up to 12). Points with a precision level are converted from the
+// south-west corner of their cell.
+func ToGeohash(v Value, chars int) string {
+	lat, lon, err := Decode(v)
+	if err != nil || chars <= 0 {
+		return ""
+	}
+	if chars > geohashMaxLength {
+		chars = geohashMaxLength
+	}
+
+	// Longitude occupies odd bits, so that the first bit is a longitude one
+	hash := interleave(encodeRange(lat, 90), encodeRange(lon, 180))
+
+	var buf [geohashMaxLength]byte
+	for i := 0; i < chars; i++ {
+		buf[i] = geohashAlphabet[(hash>>(59-5*uint(i)))&0x1F]
+	}
+
+	return string(buf[:chars])
+}
+
+// FromGeohash returns the point at the center of the given geohash cell
+func FromGeohash(hash string) (Value, error) {
+	if len(hash) == 0 || len(hash) > geohashMaxLength {
+		return Empty, ErrInvalidGeohash
+	}
+
+	// Decode characters
+	bits := uint(5 * len(hash))
+	value := uint64(0)
+	for i := 0; i < len(hash); i++ {
+		c := geohashDecoding[hash[i]]
+		if c == 0xFF {
+			return Empty, ErrInvalidGeohash
+		}
+		value = value<<5 | uint64(c)
+	}
+	value <<= 64 - bits
+
+	// Rebuild cell
+	latInt, lonInt := deinterleave(value)
+	latBits := bits / 2
+	lonBits := bits - latBits
+	lat := decodeRange(latInt, 90) + math.Ldexp(180, -int(latBits))/2
+	lon := decodeRange(lonInt, 180) + math.Ldexp(360, -int(lonBits))/2
+
+	return Encode(lat, lon), nil
+}
+
+// -----------------------------------------------------------------------------
+// copied from https://github.com/mmcloughlin/geohash/blob/master/geohash.go
+
+// Encode the position of x within the range -r to +r as a 32-bit integer.
+func encodeRange(x, r float64) uint32 {
+	p := (x + r) / (2 * r)
+	// Upper bound belongs to the last cell
+	if p >= 1 {
+		return math.MaxUint32
+	}
+	return uint32(p * (1 << 32))
+}
+
+// Decode the 32-bit range encoding X back to a value in the range -r to +r.
+func decodeRange(X uint32, r float64) float64 {
+	p := float64(X) / (1 << 32)
+	x := 2*r*p - r
+	return x
+}
@@ -0,0 +1,132 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package geopoint_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"go.zenithar.org/geopoint"
+)
+
+func TestToGeohash(t *testing.T) {
+	// Value only keeps 6 decimals, hashes are compared up to 9 characters
+	// (22 latitude bits, 23 longitude bits). Cases moved to a neighbor cell
+	// by the rounding are skipped.
+	compared := 0
+	for _, tc := range geohashTestCases {
+		lat, lng := math.Round(tc.lat*1e6)/1e6, math.Round(tc.lng*1e6)/1e6
+		if !sameGeohashCell(tc.lat, lat, 90, 22) || !sameGeohashCell(tc.lng, lng, 180, 23) {
+			continue
+		}
+
+		out := geopoint.ToGeohash(geopoint.Encode(tc.lat, tc.lng), 9)
+		if out != tc.hash[:9] {
+			t.Fatalf("Invalid result for (%f, %f): expected %q, got %q", tc.lat, tc.lng, tc.hash[:9], out)
+		}
+		compared++
+	}
+	if compared < len(geohashTestCases)*9/10 {
+		t.Fatalf("Invalid result: only %d cases out of %d compared", compared, len(geohashTestCases))
+	}
+
+	testCases := []struct {
+		lat, lon float64
+		chars    int
+		expected string
+	}{
+		{90, 179.999999, 1, "z"},
+		{90, 180, 1, "b"}, // Longitude wraps to -180
+		{-90, -180, 12, "000000000000"},
+		{0.497818518, 38.198505253, 0, ""},
+		{0.497818, 38.198505, 20, "sb54v4xk18j8"}, // Clamped to 12 characters
+	}
+
+	for _, tc := range testCases {
+		out := geopoint.ToGeohash(geopoint.Encode(tc.lat, tc.lon), tc.chars)
+		if out != tc.expected {
+			t.Fatalf("Invalid result: expected %q, got %q", tc.expected, out)
+		}
+	}
+
+	if out := geopoint.ToGeohash(geopoint.Empty, 12); out != "" {
+		t.Fatalf("Invalid result: expected empty hash, got %q", out)
+	}
+}
+
+func TestFromGeohash(t *testing.T) {
+	for _, tc := range geohashDecodeTestCases {
+		// Decoding is case insensitive
+		for _, hash := range []string{tc.hash, strings.ToUpper(tc.hash)} {
+			out, err := geopoint.FromGeohash(hash)
+			if err != nil {
+				t.Fatalf("Unable to decode %q, got error %v", hash, err)
+			}
+			lat, lon, err := geopoint.Decode(out)
+			if err != nil {
+				t.Fatalf("Unable to decode %d, got error %v", out, err)
+			}
+
+			// Must be the cell center, up to micro-degree rounding
+			if diff := lat - (tc.box.MinLat+tc.box.MaxLat)/2; diff > 1e-6 || diff < -1e-6 {
+				t.Fatalf("Invalid result for %q: latitude %f not centered", hash, lat)
+			}
+			if diff := lon - (tc.box.MinLng+tc.box.MaxLng)/2; diff > 1e-6 || diff < -1e-6 {
+				t.Fatalf("Invalid result for %q: longitude %f not centered", hash, lon)
+			}
+		}
+	}
+}
+
+// sameGeohashCell returns true if both coordinates belong to the same cell of
+// the given count of bits on an axis of [-max; max].
+func sameGeohashCell(a, b, max float64, bits uint) bool {
+	cells := float64(uint64(1) << bits)
+	return math.Floor((a+max)/(2*max)*cells) == math.Floor((b+max)/(2*max)*cells)
+}
+
+func TestFromGeohash_Invalid(t *testing.T) {
+	for _, hash := range []string{"", "a", "sb54v4xk18jgz", "sb54 4xk", "u4pruydqqvi"} {
+		out, err := geopoint.FromGeohash(hash)
+		if err != geopoint.ErrInvalidGeohash {
+			t.Fatalf("Invalid result for %q: expected ErrInvalidGeohash, got %v", hash, err)
+		}
+		if out != geopoint.Empty {
+			t.Fatalf("Invalid result for %q: expected Empty, got %d", hash, out)
+		}
+	}
+}
+
+func TestGeohash_RoundTrip(t *testing.T) {
+	for _, p := range randomPoints(10000) {
+		lat, lon, err := geopoint.Decode(p)
+		if err != nil {
+			t.Fatalf("Unable to decode %d, got error %v", p, err)
+		}
+
+		// 12 characters cells are smaller than a micro-degree
+		hash := geopoint.ToGeohash(p, 12)
+		out, err := geopoint.FromGeohash(hash)
+		if err != nil {
+			t.Fatalf("Unable to decode %q, got error %v", hash, err)
+		}
+		if expected := geopoint.Encode(lat, lon); out != expected {
+			t.Fatalf("Invalid round trip for %q, expected %d, got %d", hash, expected, out)
+		}
+	}
+}
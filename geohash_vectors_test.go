@@ -0,0 +1,177 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package geopoint_test
+
+// The test cases below are the first 64 entries of testcases_test.go and
+// decodecases_test.go of https://github.com/mmcloughlin/geohash (v0.10.0, MIT
+// License, Copyright (c) 2015 Michael McLoughlin), copied verbatim. They were
+// generated there with https://github.com/hkwi/python-geohash.
+
+// geohashTestCase is an encoding test case
+type geohashTestCase struct {
+	hashInt  uint64
+	hash     string
+	lat, lng float64
+}
+
+// geohashDecodeTestCase is a decoding test case
+type geohashDecodeTestCase struct {
+	hash string
+	box  Box
+}
+
+// Box is the bounding box of a geohash cell
+type Box struct {
+	MinLat float64
+	MaxLat float64
+	MinLng float64
+	MaxLng float64
+}
+
+var geohashTestCases = []geohashTestCase{
+	{0xc28a4d93b20a22f8, "sb54v4xk18jg", 0.497818518, 38.198505253},
+	{0x003558b7d15148f1, "00upjeyjb54g", -84.529178182, -174.125057287},
+	{0x949dcd034ca43b30, "kkfwu0udnhxm", -17.090238388, 14.947853282},
+	{0x7d44be93dc8c3d1f, "gp2cx4ywjhyj", 86.06108453, -43.628546008},
+	{0x801e4ccef502f590, "h0g4tmrp0cut", -85.311745894, 4.459114168},
+	{0xd90e166bb45673b6, "v471duxnbttv", 57.945830289, 49.349241965},
+	{0x81d1418fe43c871f, "h78n33z47k3j", -69.203844118, 11.314685805},
+	{0x7ef3c3f9b722a109, "gvtw7yer4bhh", 77.073040753, -3.346243298},
+	{0x03adcf02afef7916, "0fqwy0pgxxwj", -76.156584583, -136.834730089},
+	{0x644a3e6ab5fbafd6, "dj53wuppzfrx", 28.411988257, -85.123100792},
+	{0xbcd540239bd297fb, "rmbn08wvubcz", -11.597823607, 146.281448853},
+	{0xb663a5ee6c8fa0df, "qtjucvmdjyhe", -16.010823784, 120.67064801},
+	{0x4f24b9f758e2851e, "9wkcmxuswb2j", 35.419323354, -105.572143468},
+	{0x49c85bd32bc93b07, "9745rntct4xh", 17.482266365, -120.621762327},
+	{0x73213a6da3c7ca39, "fdhmnve3sz53", 57.159413941, -61.222135062},
+	{0x0f044c9a65ca1bc2, "1w24t6m5t8ew", -54.391332719, -112.262179799},
+	{0x575f88776cb53f16, "bxgshxvdqnzj", 89.33987042, -152.372551026},
+	{0xf435c4f4150cf21b, "yhuw9x0p1mt1", 72.901011648, 96.39410362},
+	{0x3cdb6f164961823d, "7meqy5k9d613", -12.857855257, -28.909737376},
+	{0xc1d93b954a63f23d, "s7dmr5bbdgt3", 20.631055855, 14.722834905},
+	{0x62f7bbb928678a26, "dcvvrf98dy52", 10.780452593, -47.821303925},
+	{0xb2179f2d04b17845, "q8ctyc84q5w4", -39.761862556, 114.906271436},
+	{0xa4b27243b74f03d0, "nkt74hxr9w1x", -64.134116191, 108.730725942},
+	{0xa179bd0cca8bb24a, "n5wvu36bjft4", -69.294877597, 99.682985421},
+	{0xa079222feba1cd94, "n1wk4czcn76t", -80.849571944, 98.920826744},
+	{0x1530f246840cc164, "2nsg4jn41m0q", -7.882319175, -173.224128085},
+	{0xdfbd1106fd0b9b7b, "vyyj21rx1fer", 83.899684283, 87.197879407},
+	{0x6965c308fd2fd59a, "e5kw627x5zbt", 19.382500173, -38.568029617},
+	{0x0c7f5145e6e372d7, "1jzp2jg6wete", -56.349801648, -125.151500328},
+	{0x4aef4c3a5c4dcbb8, "9crnsfkw9r5v", 8.187341946, -91.191271032},
+	{0x80c854b7dee04393, "h3459eyyw11t", -83.738044201, 14.137947303},
+	{0xd493a59a7aebaf1d, "uk9uc6muxfrj", 71.160606083, 13.774107755},
+	{0xe890a8694e4600a3, "x28bhubf8s0b", 2.839043217, 147.514480254},
+	{0x5eed92fed26ac0d8, "cvqt5zqkec0e", 75.450760056, -91.935835199},
+	{0xf3674c82c1a0a21a, "yemnt0q1n2j1", 64.425373268, 119.75949151},
+	{0x198d449ce5fcca9e, "366n9775zm59", -31.182648256, -120.878132359},
+	{0x0b81884da977eb75, "1f0shme9fzpr", -78.016323338, -100.355173113},
+	{0x405e9f9198615636, "81g9z4dsd5c3", 10.165892946, -174.766986944},
+	{0xded0cd3060d500b0, "vv8dud30un0c", 76.433419177, 79.652424948},
+	{0xd2c0a330de5f2835, "uc0b6d6ycwn3", 50.682496153, 34.918400151},
+	{0x0fa45fc018f86aec, "1yk5zh0sz1pf", -54.16177647, -95.316385169},
+	{0xd8b7c528794d300c, "v2vwbb3t9ns0", 50.407625844, 64.019442379},
+	{0xbc64d034131b0daf, "rjke0e0m3d6u", -14.924012658, 141.350509363},
+	{0xc3b6ae4b11e3f9b9, "sfvbwksjwgwv", 15.582323691, 42.116387781},
+	{0x860cb92613863fa9, "hs6ck9hmhszu", -65.867645766, 26.570981636},
+	{0x0b7c08facfc37b3d, "1ey0jyqgsexm", -68.871297151, -103.800341659},
+	{0x59e1d741422b35b2, "c7hxfhb25duv", 63.263412836, -117.333484316},
+	{0xab8c832252abce1d, "pf6868kkpg71", -77.297727015, 172.381661592},
+	{0x8c67ac1d8c9c3fc7, "jjmus7ddmhzw", -59.658095434, 53.27636308},
+	{0xdbf4141d69242eb8, "vgu187c94hrc", 66.378215883, 84.388142665},
+	{0x669c31b670e9bbc6, "duf33emhx6xw", 26.95711635, -53.01283175},
+	{0x742220e83702e057, "fhj21u1r0ch5", 67.523180302, -82.5385289},
+	{0xe359b46aba47ee30, "wedv8upu8zr3", 20.677081069, 116.410831463},
+	{0xee06355c03e2103f, "xs33br03w883", 24.252579808, 159.269421738},
+	{0x4adbbece3f7e83cd, "9cevxmjzgu1w", 9.433115669, -95.649899396},
+	{0xa247bed92c4c93c4, "n93vxq9d9k9w", -81.965793153, 115.281656662},
+	{0x754e47e28a17c1f1, "fp74gsnb2z0z", 86.286702788, -85.618138439},
+	{0xf00c1f2fe9e69795, "y061ycz9wuct", 46.723764176, 93.119722304},
+	{0x9e9d94357103c03a, "muft8ecj0g03", -17.292979155, 82.289003746},
+	{0x3998ebdf47185f7b, "76dfrru731gr", -30.498851637, -29.55832495},
+	{0x397522c6ea3b5789, "75uk5jrb7ecs", -23.174222138, -38.880806118},
+	{0x634e27bd64ca5046, "de72ggc4t984", 18.434122958, -62.763283163},
+	{0xffbd3be866569556, "zyymru36bubp", 83.915446347, 177.881387638},
+	{0xf4e3c34a93c67fbd, "ymjw6knmstzv", 74.246581453, 109.092038728},
+}
+
+var geohashDecodeTestCases = []geohashDecodeTestCase{
+	{"91rc", Box{7.20703125, 7.3828125, -124.1015625, -123.75}},
+	{"c", Box{45.0, 90.0, -135.0, -90.0}},
+	{"0fuz", Box{-73.30078125, -73.125, -139.5703125, -139.21875}},
+	{"dwfcndf", Box{38.1596374512, 38.1610107422, -63.3444213867, -63.3430480957}},
+	{"2z7", Box{-4.21875, -2.8125, -142.03125, -140.625}},
+	{"7spw2w", Box{-21.3684082031, -21.3629150391, -11.9311523438, -11.9201660156}},
+	{"eq", Box{33.75, 39.375, -33.75, -22.5}},
+	{"mgff0", Box{-23.5546875, -23.5107421875, 82.6171875, 82.6611328125}},
+	{"dp7k386jtk0", Box{41.5306591988, 41.5306605399, -85.3607976437, -85.3607963026}},
+	{"pjb", Box{-57.65625, -56.25, 135.0, 136.40625}},
+	{"jkc7uh9", Box{-62.5973510742, -62.5959777832, 58.184967041, 58.186340332}},
+	{"1gdp9", Box{-68.994140625, -68.9501953125, -98.3935546875, -98.349609375}},
+	{"z9yj14mmnxte", Box{55.7359149121, 55.7359150797, 165.988941416, 165.988941751}},
+	{"2brk", Box{-42.890625, -42.71484375, -136.0546875, -135.703125}},
+	{"dhv5t2qh59", Box{27.3360496759, 27.3360550404, -82.7296471596, -82.7296364307}},
+	{"v", Box{45.0, 90.0, 45.0, 90.0}},
+	{"3fgd9k15b5k", Box{-29.0691630542, -29.0691617131, -96.2718147039, -96.2718133628}},
+	{"j", Box{-90.0, -45.0, 45.0, 90.0}},
+	{"b4", Box{56.25, 61.875, -180.0, -168.75}},
+	{"sb38", Box{1.40625, 1.58203125, 35.859375, 36.2109375}},
+	{"puqeug", Box{-65.4180908203, -65.4125976562, 178.099365234, 178.110351562}},
+	{"45", Box{-73.125, -67.5, -90.0, -78.75}},
+	{"34b", Box{-29.53125, -28.125, -135.0, -133.59375}},
+	{"tqb8jzn9dfqn", Box{38.0074727163, 38.0074728839, 57.2148630023, 57.2148633376}},
+	{"9x", Box{39.375, 45.0, -112.5, -101.25}},
+	{"tybf7", Box{38.3642578125, 38.408203125, 79.9365234375, 79.98046875}},
+	{"9nc", Box{37.96875, 39.375, -133.59375, -132.1875}},
+	{"pp21", Box{-49.04296875, -48.8671875, 135.0, 135.3515625}},
+	{"s6wjfu76v", Box{15.0970602036, 15.0971031189, 19.8130273819, 19.8130702972}},
+	{"wxh8ped7", Box{39.3947410583, 39.3949127197, 119.160804749, 119.161148071}},
+	{"8gr", Box{18.28125, 19.6875, -136.40625, -135.0}},
+	{"ug6hf", Box{64.1162109375, 64.16015625, 36.650390625, 36.6943359375}},
+	{"pb", Box{-90.0, -84.375, 168.75, 180.0}},
+	{"nmhvpv", Box{-60.9686279297, -60.9631347656, 108.270263672, 108.28125}},
+	{"rxgthm", Box{-0.499877929688, -0.494384765625, 162.608642578, 162.619628906}},
+	{"mj8t", Box{-13.18359375, -13.0078125, 45.703125, 46.0546875}},
+	{"rkvw", Box{-17.2265625, -17.05078125, 153.984375, 154.3359375}},
+	{"j", Box{-90.0, -45.0, 45.0, 90.0}},
+	{"u4ryw22k", Box{58.8008880615, 58.8010597229, 11.1734390259, 11.1737823486}},
+	{"96bf6jfr", Box{15.8970451355, 15.8972167969, -122.60433197, -122.603988647}},
+	{"ubhnbn2n1jvj", Box{46.2219173647, 46.2219175324, 39.3750496209, 39.3750499561}},
+	{"3gmczz", Box{-26.3726806641, -26.3671875, -92.8234863281, -92.8125}},
+	{"yb4jh3u7px0", Box{45.8890718222, 45.8890731633, 126.75542593, 126.755427271}},
+	{"9ex7rkbw1duf", Box{20.2859266475, 20.2859268151, -101.985326596, -101.98532626}},
+	{"xrkyg3mj", Box{41.9754981995, 41.9756698608, 153.079376221, 153.079719543}},
+	{"z4hn2yfzryjq", Box{57.3869894072, 57.3869895749, 140.662075169, 140.662075505}},
+	{"x357", Box{6.15234375, 6.328125, 150.8203125, 151.171875}},
+	{"v3pew", Box{51.240234375, 51.2841796875, 67.060546875, 67.1044921875}},
+	{"j1", Box{-84.375, -78.75, 45.0, 56.25}},
+	{"ec1bkph03", Box{5.70744037628, 5.70748329163, -8.60774517059, -8.60770225525}},
+	{"q4t85", Box{-30.9375, -30.8935546875, 97.8662109375, 97.91015625}},
+	{"k26z8hf", Box{-42.2492980957, -42.2479248047, 15.119934082, 15.121307373}},
+	{"p6fyq2u63", Box{-73.4281110764, -73.428068161, 150.397725105, 150.397768021}},
+	{"uqu5w2yu", Box{83.5887908936, 83.5889625549, 17.1589279175, 17.1592712402}},
+	{"terbkv", Box{18.3526611328, 18.3581542969, 78.6071777344, 78.6181640625}},
+	{"8v2nwkp", Box{30.6958007812, 30.6971740723, -145.96572876, -145.964355469}},
+	{"rbktxk2enhr", Box{-42.6030693948, -42.6030680537, 175.397682041, 175.397683382}},
+	{"r1pnfct8", Box{-38.1802368164, -38.180065155, 144.97215271, 144.972496033}},
+	{"rcmxg", Box{-36.6064453125, -36.5625, 176.616210938, 176.66015625}},
+	{"jqw7xjdt8", Box{-52.7911090851, -52.7910661697, 65.350112915, 65.3501558304}},
+	{"7mt737xd", Box{-13.4716415405, -13.4714698792, -26.3019561768, -26.301612854}},
+	{"2dprx5rg57es", Box{-32.4132534117, -32.413253244, -146.986283138, -146.986282803}},
+	{"fpr5d98ws0", Box{86.40583992, 86.4058452845, -80.0455284119, -80.045517683}},
+	{"z4cmm3", Box{61.3970947266, 61.4025878906, 136.988525391, 136.999511719}},
+}
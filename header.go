@@ -0,0 +1,69 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package geopoint
+
+// Header layout, stored in the 7 high bits left unused by the point itself:
+//
+//	63     60  59  58  57  56                                    0
+//	| version | A | T | P |              point                  |
+//
+// The version nibble identifies the point layout, the flags describe how the
+// point has been produced.
+const (
+	// Version is the encoding version produced by this package
+	Version uint8 = 0
+
+	headerShift  = 57
+	versionShift = 60
+	payloadMask  = uint64(1)<<headerShift - 1
+)
+
+// Flags defines point header flags
+type Flags uint8
+
+const (
+	// FlagPrecision is set when the point carries a precision level
+	FlagPrecision Flags = 1 << iota
+	// FlagTruncated is set when the point has been coarsened from a finer one
+	FlagTruncated
+	// FlagAnonymized is set when the point is an anonymizer output
+	FlagAnonymized
+
+	flagsMask = FlagPrecision | FlagTruncated | FlagAnonymized
+)
+
+// Has returns true if all given flags are set
+func (f Flags) Has(flags Flags) bool {
+	return f&flags == flags
+}
+
+// Version returns the encoding version of the point
+func (p Value) Version() uint8 {
+	return uint8(uint64(p) >> versionShift)
+}
+
+// Flags returns the header flags of the point
+func (p Value) Flags() Flags {
+	return Flags(uint64(p)>>headerShift) & flagsMask
+}
+
+// WithFlags returns the point with the given flags set. FlagPrecision is
+// managed by Truncate and is ignored.
+func (p Value) WithFlags(flags Flags) Value {
+	flags &= FlagTruncated | FlagAnonymized
+	return p | Value(uint64(flags)<<headerShift)
+}
@@ -0,0 +1,138 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package geopoint_test
+
+import (
+	"testing"
+
+	"go.zenithar.org/geopoint"
+)
+
+func TestValue_Header(t *testing.T) {
+	p := geopoint.Encode(43.603574, 1.442917)
+
+	tcl := []struct {
+		name            string
+		point           geopoint.Value
+		expectedVersion uint8
+		expectedFlags   geopoint.Flags
+		expectedCode    string
+	}{
+		{
+			name:            "Raw point",
+			point:           p,
+			expectedVersion: geopoint.Version,
+			expectedCode:    "10AB5:69A51:94D36",
+		},
+		{
+			name:            "Point with precision",
+			point:           geopoint.EncodeWithPrecision(43.603574, 1.442917, 10),
+			expectedVersion: geopoint.Version,
+			expectedFlags:   geopoint.FlagPrecision,
+			expectedCode:    "30AB5:69A51:8",
+		},
+		{
+			name:            "Truncated point",
+			point:           p.Truncate(10),
+			expectedVersion: geopoint.Version,
+			expectedFlags:   geopoint.FlagPrecision | geopoint.FlagTruncated,
+			expectedCode:    "70AB5:69A51:8",
+		},
+		{
+			name:            "Anonymized point",
+			point:           p.WithFlags(geopoint.FlagAnonymized),
+			expectedVersion: geopoint.Version,
+			expectedFlags:   geopoint.FlagAnonymized,
+			expectedCode:    "90AB5:69A51:94D36",
+		},
+		{
+			name:            "Anonymized and truncated point",
+			point:           p.WithFlags(geopoint.FlagAnonymized).Truncate(10),
+			expectedVersion: geopoint.Version,
+			expectedFlags:   geopoint.FlagPrecision | geopoint.FlagTruncated | geopoint.FlagAnonymized,
+			expectedCode:    "F0AB5:69A51:8",
+		},
+		{
+			name:            "Precision flag is managed by truncation",
+			point:           p.WithFlags(geopoint.FlagPrecision),
+			expectedVersion: geopoint.Version,
+			expectedCode:    "10AB5:69A51:94D36",
+		},
+		{
+			name:            "Unknown version",
+			point:           p | geopoint.Value(uint64(3)<<60),
+			expectedVersion: 3,
+			expectedCode:    "310AB5:69A51:94D36",
+		},
+	}
+
+	for _, tc := range tcl {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.point.Version() != tc.expectedVersion {
+				t.Fatalf("invalid version, expected %d, got %d", tc.expectedVersion, tc.point.Version())
+			}
+			if tc.point.Flags() != tc.expectedFlags {
+				t.Fatalf("invalid flags, expected %d, got %d", tc.expectedFlags, tc.point.Flags())
+			}
+			if tc.point.Code() != tc.expectedCode {
+				t.Fatalf("invalid code, expected %s, got %s", tc.expectedCode, tc.point.Code())
+			}
+
+			// Header must not alter decoded coordinates
+			lat, lon, _ := geopoint.Decode(tc.point)
+			expectedLat, expectedLon, _ := geopoint.Decode(p.Truncate(tc.point.Precision()))
+			if lat != expectedLat || lon != expectedLon {
+				t.Fatalf("invalid coordinates, expected (%v,%v), got (%v,%v)", expectedLat, expectedLon, lat, lon)
+			}
+
+			// Code must round trip for the current version only
+			parsed, err := geopoint.ParseCode(tc.expectedCode)
+			if tc.expectedVersion != geopoint.Version {
+				if err != geopoint.ErrUnsupportedVersion {
+					t.Fatalf("invalid error, expected %v, got %v", geopoint.ErrUnsupportedVersion, err)
+				}
+				if err := geopoint.Check(tc.expectedCode); err != geopoint.ErrUnsupportedVersion {
+					t.Fatalf("invalid error, expected %v, got %v", geopoint.ErrUnsupportedVersion, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error should not be raised, got %v", err)
+			}
+			if parsed != tc.point {
+				t.Fatalf("invalid parsed point, expected %d, got %d", tc.point, parsed)
+			}
+		})
+	}
+}
+
+func TestFlags_Has(t *testing.T) {
+	flags := geopoint.FlagPrecision | geopoint.FlagAnonymized
+
+	if !flags.Has(geopoint.FlagPrecision) {
+		t.Fatal("precision flag should be set")
+	}
+	if !flags.Has(geopoint.FlagPrecision | geopoint.FlagAnonymized) {
+		t.Fatal("precision and anonymized flags should be set")
+	}
+	if flags.Has(geopoint.FlagTruncated) {
+		t.Fatal("truncated flag should not be set")
+	}
+	if flags.Has(geopoint.FlagPrecision | geopoint.FlagTruncated) {
+		t.Fatal("truncated flag should not be set")
+	}
+}
@@ -0,0 +1,155 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package geopoint
+
+import "math/bits"
+
+// Parent returns the cell of the given level containing the point. Unlike
+// Truncate, header flags are kept as is, so that the parent of every child of a
+// cell is the cell itself. The point is returned unchanged if the level is not
+// coarser than its own.
+func Parent(v Value, level Level) Value {
+	if v == Empty || level >= v.Precision() {
+		return v
+	}
+	return v.truncate(level).WithFlags(v.Flags())
+}
+
+// Children returns the cells of the next level contained by the point cell,
+// in value order. Cells are clipped to the degree, the last cells of a degree
+// may have less than 4 children. Full precision points have no children.
+func Children(v Value) []Value {
+	if v == Empty || v.Precision() == LevelMax {
+		return nil
+	}
+
+	level := v.Precision() + 1
+	origin := uint64(v.origin()) & payloadMask
+	limit := uint32(microDegrees)
+	if origin>>49 == 180 {
+		// Latitude 90 has no fraction
+		limit = 1
+	}
+
+	// Child bits follow the parent bits, longitude first
+	shift := 2 * uint(LevelMax-level)
+	children := make([]Value, 0, 4)
+	for i := uint64(0); i < 4; i++ {
+		child := origin | i<<shift
+		if lat, lon := deinterleave(child & 0xFFFFFFFFFF); lat >= limit || lon >= microDegrees {
+			continue
+		}
+		children = append(children, childAt(Value(child), level, v.Flags()))
+	}
+
+	return children
+}
+
+// ChildBegin returns the first cell of the given level contained by the point
+// cell, full precision points are returned for LevelMax. Header flags are kept,
+// except the truncated flag at LevelMax, the level is clamped to the point
+// level.
+func ChildBegin(v Value, level Level) Value {
+	if v == Empty {
+		return Empty
+	}
+
+	minLat, minLon, _, _ := v.cellBounds()
+	return childAt(Value(coverKey(minLat, minLon)), clampChildLevel(v, level), v.Flags())
+}
+
+// ChildEnd returns the last cell of the given level contained by the point
+// cell, bounds included: every point of the cell is between ChildBegin and
+// ChildEnd at LevelMax.
+func ChildEnd(v Value, level Level) Value {
+	if v == Empty {
+		return Empty
+	}
+
+	minLat, _, maxLat, maxLon := v.cellBounds()
+	if maxLat > minLat {
+		maxLat--
+	}
+	return childAt(Value(coverKey(maxLat, maxLon-1)), clampChildLevel(v, level), v.Flags())
+}
+
+// IsAncestorOf returns true if the cell of a contains the cell of b, a cell is
+// its own ancestor. Header flags are ignored.
+func IsAncestorOf(a, b Value) bool {
+	if a == Empty || b == Empty {
+		return false
+	}
+
+	level := a.Precision()
+	if b.Precision() < level {
+		return false
+	}
+
+	return uint64(b.truncate(level))&payloadMask == uint64(a)&payloadMask
+}
+
+// CommonAncestor returns the finest cell containing both points, given by the
+// longest common prefix of their values. Degree cells are the coarsest cells,
+// the empty point is returned for points in different degrees. Only header
+// flags set on both points are kept.
+func CommonAncestor(a, b Value) Value {
+	if a == Empty || b == Empty {
+		return Empty
+	}
+
+	x, y := uint64(a.origin())&payloadMask, uint64(b.origin())&payloadMask
+	if x>>40 != y>>40 {
+		return Empty
+	}
+
+	// Shared interleaved bits, by pairs
+	level := Level(LevelMax)
+	if diff := (x ^ y) & 0xFFFFFFFFFF; diff != 0 {
+		level = Level((bits.LeadingZeros64(diff) - 24) / 2)
+	}
+	if a.Precision() < level {
+		level = a.Precision()
+	}
+	if b.Precision() < level {
+		level = b.Precision()
+	}
+
+	return childAt(Value(x), level, a.Flags()&b.Flags())
+}
+
+// -----------------------------------------------------------------------------
+
+// childAt returns the cell of the given level containing the full precision
+// point, with the given header flags. Full precision points are never
+// truncated, only the anonymized flag is kept for them.
+func childAt(v Value, level Level, flags Flags) Value {
+	if level < LevelMax {
+		return v.truncate(level).WithFlags(flags)
+	}
+	return v.WithFlags(flags & FlagAnonymized)
+}
+
+// clampChildLevel returns the level bounded by the point level and LevelMax
+func clampChildLevel(v Value, level Level) Level {
+	switch {
+	case level < v.Precision():
+		return v.Precision()
+	case level > LevelMax:
+		return LevelMax
+	}
+	return level
+}
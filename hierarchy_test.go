@@ -0,0 +1,226 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package geopoint_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"go.zenithar.org/geopoint"
+)
+
+func TestParent(t *testing.T) {
+	p := geopoint.Encode(43.603574, 1.442917)
+
+	for level := geopoint.LevelDegree; level < geopoint.LevelMax; level++ {
+		parent := geopoint.Parent(p, level)
+		if expected := geopoint.EncodeWithPrecision(43.603574, 1.442917, level); parent != expected {
+			t.Fatalf("Invalid result: expected %+v but got %+v", expected, parent)
+		}
+		if geopoint.Parent(parent, level+1) != parent {
+			t.Fatalf("Invalid result: finer parent of %s should be itself", parent)
+		}
+	}
+
+	// Header flags are kept
+	anonymized := p.WithFlags(geopoint.FlagAnonymized)
+	if out := geopoint.Parent(anonymized, 10); out.Flags() != geopoint.FlagPrecision|geopoint.FlagAnonymized {
+		t.Fatalf("Invalid result: expected anonymized parent, got %+v", out)
+	}
+	if geopoint.Parent(geopoint.Empty, 10) != geopoint.Empty {
+		t.Fatalf("Invalid result: empty point should have no parent")
+	}
+}
+
+func TestChildren(t *testing.T) {
+	tcl := []struct {
+		name     string
+		value    geopoint.Value
+		expected int
+	}{
+		{name: "Degree", value: geopoint.EncodeWithPrecision(43.6, 1.4, 0), expected: 4},
+		{name: "Level 12", value: geopoint.EncodeWithPrecision(43.6, 1.4, 12), expected: 4},
+		{name: "Clipped latitude", value: geopoint.EncodeWithPrecision(43.99999, 1.4, 13), expected: 2},
+		{name: "Clipped corner", value: geopoint.EncodeWithPrecision(43.99999, 1.99999, 13), expected: 1},
+		{name: "North pole", value: geopoint.EncodeWithPrecision(90, 1.4, 5), expected: 2},
+		{name: "Full precision", value: geopoint.Encode(43.6, 1.4), expected: 0},
+		{name: "Empty", value: geopoint.Empty, expected: 0},
+	}
+
+	for _, tc := range tcl {
+		t.Run(tc.name, func(t *testing.T) {
+			children := geopoint.Children(tc.value)
+			if len(children) != tc.expected {
+				t.Fatalf("Invalid result: expected %d children but got %v", tc.expected, children)
+			}
+			for i, c := range children {
+				if !c.IsValid() || c.Precision() != tc.value.Precision()+1 {
+					t.Fatalf("Invalid result: invalid child %+v", c)
+				}
+				if geopoint.Parent(c, tc.value.Precision()) != tc.value {
+					t.Fatalf("Invalid result: parent of %s should be %s", c, tc.value)
+				}
+				if i > 0 && c <= children[i-1] {
+					t.Fatalf("Invalid result: children should be sorted, got %v", children)
+				}
+			}
+		})
+	}
+}
+
+func TestChildBegin(t *testing.T) {
+	cell := geopoint.EncodeWithPrecision(43.603574, 1.442917, 10)
+
+	if out, expected := geopoint.ChildBegin(cell, geopoint.LevelMax), geopoint.Encode(43.603136, 1.442368); out != expected {
+		t.Fatalf("Invalid result: expected %+v but got %+v", expected, out)
+	}
+	if out, expected := geopoint.ChildEnd(cell, geopoint.LevelMax), geopoint.Encode(43.604159, 1.443391); out != expected {
+		t.Fatalf("Invalid result: expected %+v but got %+v", expected, out)
+	}
+	if out, expected := geopoint.ChildBegin(cell, 11), geopoint.Children(cell)[0]; out != expected {
+		t.Fatalf("Invalid result: expected %+v but got %+v", expected, out)
+	}
+	if out, expected := geopoint.ChildEnd(cell, 11), geopoint.Children(cell)[3]; out != expected {
+		t.Fatalf("Invalid result: expected %+v but got %+v", expected, out)
+	}
+	if out := geopoint.ChildBegin(cell, 5); out != cell {
+		t.Fatalf("Invalid result: coarser level should be clamped, got %+v", out)
+	}
+
+	// Every point of a cell is in its child range
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		p := geopoint.Encode(r.Float64()*180-90, r.Float64()*360-180)
+		cell := geopoint.Parent(p, geopoint.Level(r.Intn(20)))
+		begin, end := geopoint.ChildBegin(cell, geopoint.LevelMax), geopoint.ChildEnd(cell, geopoint.LevelMax)
+		if p < begin || p > end {
+			t.Fatalf("Invalid result: %s should be in [%s; %s]", p, begin, end)
+		}
+		if geopoint.Parent(begin, cell.Precision()) != cell || geopoint.Parent(end, cell.Precision()) != cell {
+			t.Fatalf("Invalid result: [%s; %s] should be in %s", begin, end, cell)
+		}
+	}
+}
+
+func TestChildBegin_Truncated(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		p := geopoint.Encode(r.Float64()*180-90, r.Float64()*360-180)
+		if i%2 == 0 {
+			p = p.WithFlags(geopoint.FlagAnonymized)
+		}
+		cell := p.Truncate(geopoint.Level(r.Intn(20)))
+
+		begin, end := geopoint.ChildBegin(cell, geopoint.LevelMax), geopoint.ChildEnd(cell, geopoint.LevelMax)
+		if !begin.IsValid() || !end.IsValid() {
+			t.Fatalf("Invalid result: child range of %+v should be valid, got [%+v; %+v]", cell, begin, end)
+		}
+		if begin.Flags() != p.Flags() || end.Flags() != p.Flags() {
+			t.Fatalf("Invalid result: child range of %+v should have the point flags, got [%+v; %+v]", cell, begin, end)
+		}
+		if p < begin || p > end {
+			t.Fatalf("Invalid result: %s should be in [%s; %s]", p, begin, end)
+		}
+
+		for _, c := range geopoint.Children(cell) {
+			if !c.IsValid() {
+				t.Fatalf("Invalid result: children of %+v should be valid, got %+v", cell, c)
+			}
+		}
+	}
+
+	// Full precision children of a level 19 cell
+	cell := geopoint.Encode(43.603574, 1.442917).Truncate(19)
+	for _, c := range geopoint.Children(cell) {
+		if !c.IsValid() || c.Flags() != 0 {
+			t.Fatalf("Invalid result: expected full precision child, got %+v", c)
+		}
+	}
+}
+
+func TestIsAncestorOf(t *testing.T) {
+	p := geopoint.Encode(43.603574, 1.442917)
+
+	tcl := []struct {
+		name     string
+		a, b     geopoint.Value
+		expected bool
+	}{
+		{name: "Parent", a: geopoint.Parent(p, 10), b: p, expected: true},
+		{name: "Degree", a: geopoint.Parent(p, 0), b: geopoint.Parent(p, 19), expected: true},
+		{name: "Itself", a: geopoint.Parent(p, 10), b: geopoint.Parent(p, 10), expected: true},
+		{name: "Child", a: p, b: geopoint.Parent(p, 10)},
+		{name: "Other cell", a: geopoint.Parent(p, 10), b: geopoint.Encode(43.7, 1.4)},
+		{name: "Other degree", a: geopoint.Parent(p, 0), b: geopoint.Encode(44.603574, 1.442917)},
+		{name: "Flags ignored", a: p.Truncate(10), b: p.WithFlags(geopoint.FlagAnonymized), expected: true},
+		{name: "Empty", a: geopoint.Empty, b: p},
+	}
+
+	for _, tc := range tcl {
+		t.Run(tc.name, func(t *testing.T) {
+			if out := geopoint.IsAncestorOf(tc.a, tc.b); out != tc.expected {
+				t.Fatalf("Invalid result: expected %v but got %v", tc.expected, out)
+			}
+		})
+	}
+}
+
+func TestCommonAncestor(t *testing.T) {
+	p := geopoint.Encode(43.603574, 1.442917)
+
+	tcl := []struct {
+		name     string
+		a, b     geopoint.Value
+		expected geopoint.Value
+	}{
+		{name: "Same point", a: p, b: p, expected: p},
+		{name: "Neighbor", a: p, b: geopoint.Encode(43.603575, 1.442917), expected: geopoint.Parent(p, 19)},
+		{name: "Same degree", a: geopoint.Encode(43.1, 1.1), b: geopoint.Encode(43.9, 1.9), expected: geopoint.EncodeWithPrecision(43, 1, 0)},
+		{name: "Ancestor", a: geopoint.Parent(p, 7), b: p, expected: geopoint.Parent(p, 7)},
+		{name: "Level bounded", a: geopoint.Parent(p, 7), b: geopoint.Parent(p, 12), expected: geopoint.Parent(p, 7)},
+		{name: "Common flags", a: p.WithFlags(geopoint.FlagAnonymized), b: p.Truncate(3), expected: geopoint.Parent(p, 3)},
+		{name: "Other degree", a: p, b: geopoint.Encode(44.603574, 1.442917), expected: geopoint.Empty},
+		{name: "Empty", a: geopoint.Empty, b: p, expected: geopoint.Empty},
+	}
+
+	for _, tc := range tcl {
+		t.Run(tc.name, func(t *testing.T) {
+			if out := geopoint.CommonAncestor(tc.a, tc.b); out != tc.expected {
+				t.Fatalf("Invalid result: expected %+v but got %+v", tc.expected, out)
+			}
+			if out := geopoint.CommonAncestor(tc.b, tc.a); out != tc.expected {
+				t.Fatalf("Invalid result: expected %+v but got %+v (reversed)", tc.expected, out)
+			}
+		})
+	}
+
+	// Common ancestor contains both points, its children do not
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		a := geopoint.Encode(43+r.Float64(), 1+r.Float64())
+		b := geopoint.Encode(43+r.Float64()/float64(i+1), 1+r.Float64()/float64(i+1))
+		c := geopoint.CommonAncestor(a, b)
+		if !geopoint.IsAncestorOf(c, a) || !geopoint.IsAncestorOf(c, b) {
+			t.Fatalf("Invalid result: %s should contain %s and %s", c, a, b)
+		}
+		for _, child := range geopoint.Children(c) {
+			if geopoint.IsAncestorOf(child, a) && geopoint.IsAncestorOf(child, b) {
+				t.Fatalf("Invalid result: %s is a finer common ancestor of %s and %s than %s", child, a, b, c)
+			}
+		}
+	}
+}
@@ -0,0 +1,155 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package geopoint
+
+// Maidenhead locator system.
+//
+// A locator is made of pairs of characters, longitude first, each pair
+// splitting the parent cell:
+//
+//	pair  name                  chars  cell size (lon x lat)
+//	   1  field                 A-R    20°     x 10°
+//	   2  square                0-9    2°      x 1°
+//	   3  subsquare             a-x    5'      x 2.5'
+//	   4  extended square       0-9    30"     x 15"
+//	   5  extended subsquare    a-x    1.25"   x 0.625"
+//
+// Micro-degrees are scaled by 576 (24²) so that every cell boundary is an
+// integer and the conversion is exact.
+const (
+	maidenheadMinPairs = 2
+	maidenheadMaxPairs = 5
+	// Number of cells along each axis at the finest level
+	maidenheadCells = 18 * 10 * 24 * 10 * 24
+	// Scale applied to micro-degrees
+	maidenheadScale = 24 * 24
+	// Size of the finest cells, in scaled micro-degrees
+	maidenheadLonUnit = 360 * microDegrees * maidenheadScale / maidenheadCells
+	maidenheadLatUnit = 180 * microDegrees * maidenheadScale / maidenheadCells
+)
+
+var (
+	maidenheadBases = [maidenheadMaxPairs]int64{18, 10, 24, 10, 24}
+)
+
+// ToMaidenhead returns the Maidenhead locator of the given point with the given
+// number of pairs (from 2 to 5). Points with a precision level are converted
+// from the south-west corner of their cell.
+func ToMaidenhead(v Value, pairs int) (string, error) {
+	if pairs < maidenheadMinPairs || pairs > maidenheadMaxPairs {
+		return "", ErrInvalidMaidenhead
+	}
+	lat, lon, err := Decode(v)
+	if err != nil {
+		return "", err
+	}
+
+	// Finest cell indices, the north pole belongs to the last row
+	lonIdx := (toMicroDegrees(lon) + 180*microDegrees) * maidenheadScale / maidenheadLonUnit
+	latIdx := (toMicroDegrees(lat) + 90*microDegrees) * maidenheadScale / maidenheadLatUnit
+	if latIdx >= maidenheadCells {
+		latIdx = maidenheadCells - 1
+	}
+
+	var buf [2 * maidenheadMaxPairs]byte
+	for i := maidenheadMaxPairs - 1; i >= 0; i-- {
+		base := maidenheadBases[i]
+		if i < pairs {
+			buf[2*i] = maidenheadChar(i, lonIdx%base)
+			buf[2*i+1] = maidenheadChar(i, latIdx%base)
+		}
+		lonIdx /= base
+		latIdx /= base
+	}
+
+	return string(buf[:2*pairs]), nil
+}
+
+// FromMaidenhead returns the center and the bounds of the given Maidenhead
+// locator. Parsing is case-insensitive.
+func FromMaidenhead(locator string) (Value, Rect, error) {
+	pairs := len(locator) / 2
+	if len(locator)%2 == 1 || pairs < maidenheadMinPairs || pairs > maidenheadMaxPairs {
+		return Empty, Rect{}, ErrInvalidMaidenhead
+	}
+
+	// Decode cell indices
+	lonIdx, latIdx := int64(0), int64(0)
+	for i := 0; i < pairs; i++ {
+		lonDigit, ok := maidenheadDigit(i, locator[2*i])
+		if !ok {
+			return Empty, Rect{}, ErrInvalidMaidenhead
+		}
+		latDigit, ok := maidenheadDigit(i, locator[2*i+1])
+		if !ok {
+			return Empty, Rect{}, ErrInvalidMaidenhead
+		}
+		lonIdx = lonIdx*maidenheadBases[i] + lonDigit
+		latIdx = latIdx*maidenheadBases[i] + latDigit
+	}
+
+	// Cell size, in finest cells
+	size := int64(1)
+	for i := pairs; i < maidenheadMaxPairs; i++ {
+		size *= maidenheadBases[i]
+	}
+
+	toDegrees := func(scaled int64, offset float64) float64 {
+		return float64(scaled)/(microDegrees*maidenheadScale) - offset
+	}
+	minLon, minLat := lonIdx*size*maidenheadLonUnit, latIdx*size*maidenheadLatUnit
+	maxLon, maxLat := minLon+size*maidenheadLonUnit, minLat+size*maidenheadLatUnit
+	bounds := Rect{
+		MinLat: toDegrees(minLat, 90),
+		MinLon: toDegrees(minLon, 180),
+		MaxLat: toDegrees(maxLat, 90),
+		MaxLon: toDegrees(maxLon, 180),
+	}
+
+	return Encode(toDegrees((minLat+maxLat)/2, 90), toDegrees((minLon+maxLon)/2, 180)), bounds, nil
+}
+
+// -----------------------------------------------------------------------------
+
+// maidenheadChar returns the character of the given digit of the given pair.
+func maidenheadChar(pair int, digit int64) byte {
+	switch pair {
+	case 0:
+		return 'A' + byte(digit)
+	case 1, 3:
+		return '0' + byte(digit)
+	default:
+		return 'a' + byte(digit)
+	}
+}
+
+// maidenheadDigit returns the digit of the given character of the given pair.
+func maidenheadDigit(pair int, c byte) (int64, bool) {
+	var digit int64
+	switch {
+	case pair == 1 || pair == 3:
+		digit = int64(c) - '0'
+	case c >= 'a' && c <= 'z':
+		digit = int64(c) - 'a'
+	case c >= 'A' && c <= 'Z':
+		digit = int64(c) - 'A'
+	default:
+		return 0, false
+	}
+
+	return digit, digit >= 0 && digit < maidenheadBases[pair]
+}
@@ -0,0 +1,150 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package geopoint_test
+
+import (
+	"math"
+	"testing"
+
+	"go.zenithar.org/geopoint"
+)
+
+func TestToMaidenhead(t *testing.T) {
+	testCases := []struct {
+		lat, lon float64
+		pairs    int
+		expected string
+	}{
+		// Well known locators
+		{48.14666, 11.60833, 3, "JN58td"},
+		{-34.91, -56.21166, 3, "GF15vc"},
+		{38.92, -77.065, 3, "FM18lw"},
+		{-41.28333, 174.745, 3, "RE78ir"},
+		{41.714775, -72.727260, 3, "FN31pr"},
+		{41.714775, -72.727260, 5, "FN31pr21rn"},
+		{43.603574, 1.442917, 2, "JN03"},
+		{43.603574, 1.442917, 4, "JN03ro34"},
+		// Corners
+		{-90, -180, 5, "AA00aa00aa"},
+		{90, 179.999999, 5, "RR99xx99xx"},
+	}
+
+	for _, tc := range testCases {
+		out, err := geopoint.ToMaidenhead(geopoint.Encode(tc.lat, tc.lon), tc.pairs)
+		if err != nil {
+			t.Fatalf("Unable to encode (%f, %f), got error %v", tc.lat, tc.lon, err)
+		}
+		if out != tc.expected {
+			t.Fatalf("Invalid result for (%f, %f): expected %q, got %q", tc.lat, tc.lon, tc.expected, out)
+		}
+	}
+}
+
+func TestToMaidenhead_Invalid(t *testing.T) {
+	p := geopoint.Encode(43.603574, 1.442917)
+
+	tcl := []struct {
+		name        string
+		point       geopoint.Value
+		pairs       int
+		expectedErr error
+	}{
+		{name: "Negative pairs", point: p, pairs: -1, expectedErr: geopoint.ErrInvalidMaidenhead},
+		{name: "No pairs", point: p, pairs: 0, expectedErr: geopoint.ErrInvalidMaidenhead},
+		{name: "Too few pairs", point: p, pairs: 1, expectedErr: geopoint.ErrInvalidMaidenhead},
+		{name: "Too many pairs", point: p, pairs: 6, expectedErr: geopoint.ErrInvalidMaidenhead},
+		{name: "Way too many pairs", point: p, pairs: 9, expectedErr: geopoint.ErrInvalidMaidenhead},
+		{name: "Empty point", point: geopoint.Empty, pairs: 3, expectedErr: geopoint.ErrEmptyGeoPoint},
+	}
+
+	for _, tc := range tcl {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := geopoint.ToMaidenhead(tc.point, tc.pairs)
+			if err != tc.expectedErr {
+				t.Fatalf("Invalid result: Error expected %v, got %v.", tc.expectedErr, err)
+			}
+			if out != "" {
+				t.Fatalf("Invalid result: expected empty locator, got %q", out)
+			}
+		})
+	}
+}
+
+func TestFromMaidenhead(t *testing.T) {
+	testCases := []struct {
+		locator  string
+		lat, lon float64
+		bounds   geopoint.Rect
+	}{
+		{"JN58", 48.5, 11, geopoint.Rect{MinLat: 48, MinLon: 10, MaxLat: 49, MaxLon: 12}},
+		{"jn58TD", 48.145833, 11.625, geopoint.Rect{MinLat: 48.125, MinLon: 11.583333, MaxLat: 48.166667, MaxLon: 11.666667}},
+		{"FN31pr21", 41.714583, -72.729167, geopoint.Rect{MinLat: 41.7125, MinLon: -72.733333, MaxLat: 41.716667, MaxLon: -72.725}},
+		{"RR99XX99XX", 89.999913, 179.999826, geopoint.Rect{MinLat: 89.999826, MinLon: 179.999653, MaxLat: 90, MaxLon: 180}},
+	}
+
+	for _, tc := range testCases {
+		out, bounds, err := geopoint.FromMaidenhead(tc.locator)
+		if err != nil {
+			t.Fatalf("Unable to decode %q, got error %v", tc.locator, err)
+		}
+		if expected := geopoint.Encode(tc.lat, tc.lon); out != expected {
+			lat, lon, _ := geopoint.Decode(out)
+			t.Fatalf("Invalid result for %q: expected (%f, %f), got (%f, %f)", tc.locator, tc.lat, tc.lon, lat, lon)
+		}
+		if math.Abs(bounds.MinLat-tc.bounds.MinLat) > 1e-6 || math.Abs(bounds.MinLon-tc.bounds.MinLon) > 1e-6 ||
+			math.Abs(bounds.MaxLat-tc.bounds.MaxLat) > 1e-6 || math.Abs(bounds.MaxLon-tc.bounds.MaxLon) > 1e-6 {
+			t.Fatalf("Invalid result for %q: expected %+v, got %+v", tc.locator, tc.bounds, bounds)
+		}
+	}
+}
+
+func TestFromMaidenhead_Invalid(t *testing.T) {
+	for _, locator := range []string{"", "J", "JN", "JN5", "JN58t", "SN58", "JA5A", "JN58ty", "JN58td1", "JN58td1a", "JN58td12y", "JN58td12yy0", "JN 8", "ÉN58"} {
+		out, bounds, err := geopoint.FromMaidenhead(locator)
+		if err != geopoint.ErrInvalidMaidenhead {
+			t.Fatalf("Invalid result for %q: expected ErrInvalidMaidenhead, got %v", locator, err)
+		}
+		if out != geopoint.Empty || bounds != (geopoint.Rect{}) {
+			t.Fatalf("Invalid result for %q: expected Empty, got %d", locator, out)
+		}
+	}
+}
+
+func TestMaidenhead_RoundTrip(t *testing.T) {
+	for _, p := range randomPoints(10000) {
+		for pairs := 2; pairs <= 5; pairs++ {
+			locator, err := geopoint.ToMaidenhead(p, pairs)
+			if err != nil {
+				t.Fatalf("Unable to encode %d, got error %v", p, err)
+			}
+			center, bounds, err := geopoint.FromMaidenhead(locator)
+			if err != nil {
+				t.Fatalf("Unable to decode %q, got error %v", locator, err)
+			}
+
+			// The point belongs to the cell, and the cell center to the same locator
+			const epsilon = 1e-9
+			lat, lon, _ := geopoint.Decode(p)
+			if lat < bounds.MinLat-epsilon || lat > bounds.MaxLat+epsilon || lon < bounds.MinLon-epsilon || lon > bounds.MaxLon+epsilon {
+				t.Fatalf("Invalid result for %q: (%f, %f) is not in %+v", locator, lat, lon, bounds)
+			}
+			if out, err := geopoint.ToMaidenhead(center, pairs); err != nil || out != locator {
+				t.Fatalf("Invalid round trip for %q, got %q (%v)", locator, out, err)
+			}
+		}
+	}
+}
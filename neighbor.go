@@ -0,0 +1,94 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package geopoint
+
+// Direction is a neighbor cell direction
+type Direction uint8
+
+const (
+	// North is the cell above
+	North Direction = iota
+	// NorthEast is the cell above, on the right
+	NorthEast
+	// East is the cell on the right
+	East
+	// SouthEast is the cell below, on the right
+	SouthEast
+	// South is the cell below
+	South
+	// SouthWest is the cell below, on the left
+	SouthWest
+	// West is the cell on the left
+	West
+	// NorthWest is the cell above, on the left
+	NorthWest
+)
+
+// Opposite returns the opposite direction
+func (d Direction) Opposite() Direction {
+	return (d + 4) % 8
+}
+
+// Neighbors returns the 8 adjacent cells of the point at its precision level,
+// indexed by direction.
+func Neighbors(v Value) [8]Value {
+	var out [8]Value
+	for d := range out {
+		out[d] = Neighbor(v, Direction(d))
+	}
+	return out
+}
+
+// Neighbor returns the adjacent cell of the point in the given direction, at
+// the same precision level and with the same header flags. Longitudes wrap
+// around ±180, there is no cell beyond the poles and the empty point is
+// returned. Latitude 90 has its own row of cells.
+func Neighbor(v Value, d Direction) Value {
+	if v == Empty || d > NorthWest {
+		return Empty
+	}
+
+	// Move to a micro-degree of the adjacent cell, cell bounds handle the carry
+	// between the fractions and the degree fields.
+	minLat, minLon, maxLat, maxLon := v.cellBounds()
+	lat, lon := minLat, minLon
+	switch d {
+	case North, NorthEast, NorthWest:
+		if minLat == 180*microDegrees {
+			return Empty
+		}
+		lat = maxLat
+	case South, SouthEast, SouthWest:
+		if minLat == 0 {
+			return Empty
+		}
+		lat = minLat - 1
+	}
+	switch d {
+	case East, NorthEast, SouthEast:
+		lon = maxLon % (360 * microDegrees)
+	case West, NorthWest, SouthWest:
+		lon = (minLon + 360*microDegrees - 1) % (360 * microDegrees)
+	}
+
+	out := Value(coverKey(lat, lon))
+	if v.Flags().Has(FlagPrecision) {
+		out = out.truncate(v.Precision())
+	}
+
+	return out.WithFlags(v.Flags())
+}
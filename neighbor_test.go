@@ -0,0 +1,99 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package geopoint_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"go.zenithar.org/geopoint"
+)
+
+func TestNeighbor(t *testing.T) {
+	tcl := []struct {
+		name      string
+		value     geopoint.Value
+		direction geopoint.Direction
+		expected  geopoint.Value
+	}{
+		{name: "Full precision north", value: geopoint.Encode(43.603574, 1.442917), direction: geopoint.North, expected: geopoint.Encode(43.603575, 1.442917)},
+		{name: "Full precision south west", value: geopoint.Encode(43.603574, 1.442917), direction: geopoint.SouthWest, expected: geopoint.Encode(43.603573, 1.442916)},
+		{name: "Degree carry", value: geopoint.Encode(43.999999, 1.999999), direction: geopoint.NorthEast, expected: geopoint.Encode(44, 2)},
+		{name: "Degree borrow", value: geopoint.Encode(44, 2), direction: geopoint.SouthWest, expected: geopoint.Encode(43.999999, 1.999999)},
+		{name: "Level 0", value: geopoint.EncodeWithPrecision(43.5, 1.5, 0), direction: geopoint.East, expected: geopoint.EncodeWithPrecision(43.5, 2.5, 0)},
+		{name: "Level 10", value: geopoint.EncodeWithPrecision(43.603574, 1.442917, 10), direction: geopoint.North, expected: geopoint.EncodeWithPrecision(43.60416, 1.442917, 10)},
+		{name: "Clipped cell", value: geopoint.EncodeWithPrecision(43.9, 1.5, 1), direction: geopoint.North, expected: geopoint.EncodeWithPrecision(44, 1.5, 1)},
+		{name: "Into clipped cell", value: geopoint.EncodeWithPrecision(44.1, 1.5, 1), direction: geopoint.South, expected: geopoint.EncodeWithPrecision(43.524288, 1.5, 1)},
+		{name: "Antimeridian east", value: geopoint.Encode(0, 179.999999), direction: geopoint.East, expected: geopoint.Encode(0, -180)},
+		{name: "Antimeridian west", value: geopoint.EncodeWithPrecision(0, -180, 5), direction: geopoint.West, expected: geopoint.EncodeWithPrecision(0, 179.99, 5)},
+		{name: "North pole row", value: geopoint.EncodeWithPrecision(89.99, 0, 5), direction: geopoint.North, expected: geopoint.EncodeWithPrecision(90, 0, 5)},
+		{name: "Beyond north pole", value: geopoint.EncodeWithPrecision(90, 0, 5), direction: geopoint.NorthEast, expected: geopoint.Empty},
+		{name: "Beyond south pole", value: geopoint.Encode(-90, 0), direction: geopoint.South, expected: geopoint.Empty},
+		{name: "Flags", value: geopoint.Encode(0, 0).WithFlags(geopoint.FlagAnonymized).Truncate(3), direction: geopoint.West, expected: geopoint.EncodeWithPrecision(0, -0.05, 3).WithFlags(geopoint.FlagAnonymized | geopoint.FlagTruncated)},
+		{name: "Empty", value: geopoint.Empty, direction: geopoint.North, expected: geopoint.Empty},
+	}
+
+	for _, tc := range tcl {
+		t.Run(tc.name, func(t *testing.T) {
+			if out := geopoint.Neighbor(tc.value, tc.direction); out != tc.expected {
+				t.Fatalf("Invalid result: expected %+v but got %+v", tc.expected, out)
+			}
+		})
+	}
+}
+
+func TestNeighbors_Symmetry(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	values := randomPoints(10000)
+	for i := 0; i < 100; i++ {
+		// Edge cells
+		level := geopoint.Level(r.Intn(20))
+		values = append(values,
+			geopoint.EncodeWithPrecision(90, r.Float64()*360-180, level),
+			geopoint.EncodeWithPrecision(-90, r.Float64()*360-180, level),
+			geopoint.EncodeWithPrecision(r.Float64()*180-90, 179.999999, level),
+			geopoint.EncodeWithPrecision(r.Float64()*180-90, -180, level),
+			geopoint.EncodeWithPrecision(float64(r.Intn(180)-90)+0.999999, float64(r.Intn(360)-180)+0.999999, level),
+		)
+	}
+
+	for _, v := range values {
+		neighbors := geopoint.Neighbors(v)
+		for d, n := range neighbors {
+			direction := geopoint.Direction(d)
+			if n == geopoint.Empty {
+				continue
+			}
+			if n == v {
+				t.Fatalf("Invalid result: %s should not be its own %d neighbor", v, d)
+			}
+			if n.Precision() != v.Precision() || n.Flags() != v.Flags() {
+				t.Fatalf("Invalid result: %+v neighbor %d should have the same level and flags, got %+v", v, d, n)
+			}
+			if back := geopoint.Neighbor(n, direction.Opposite()); back != v {
+				t.Fatalf("Invalid result: %s is the %d neighbor of %s, but its opposite neighbor is %s", n, d, v, back)
+			}
+
+			// Cells touch, unless across the antimeridian
+			bounds := v.Bounds()
+			if bounds.MinLon != -180 && bounds.MaxLon != 180 && !n.Bounds().Intersects(bounds) {
+				t.Fatalf("Invalid result: %s neighbor %d should touch it, got %s", v, d, n)
+			}
+		}
+	}
+}
@@ -0,0 +1,96 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package olc
+
+import (
+	"math"
+	"strings"
+)
+
+// CodeArea is the area covered by a code.
+type CodeArea struct {
+	LatLo, LngLo, LatHi, LngHi float64
+	// Len is the number of digits of the code
+	Len int
+}
+
+// Center returns the center of the area, in degrees.
+func (area CodeArea) Center() (lat, lng float64) {
+	lat = math.Min(area.LatLo+(area.LatHi-area.LatLo)/2, latMax)
+	lng = math.Min(area.LngLo+(area.LngHi-area.LngLo)/2, lngMax)
+	return lat, lng
+}
+
+// Decode returns the area covered by the given full code. Digits after the
+// 15th are ignored.
+func Decode(code string) (CodeArea, error) {
+	if err := CheckFull(code); err != nil {
+		return CodeArea{}, err
+	}
+
+	// Strip separator and padding
+	code = strings.Replace(code, string(Separator), "", 1)
+	code = strings.TrimRight(code, string(Padding))
+	if len(code) > maxCodeLen {
+		code = code[:maxCodeLen]
+	}
+
+	// Pair digits, in pair precision units
+	latVal, lngVal := int64(-latMax*pairPrecision), int64(-lngMax*pairPrecision)
+	pv := int64(pairFirstPlaceValue)
+	digits := len(code)
+	if digits > pairCodeLen {
+		digits = pairCodeLen
+	}
+	for i := 0; i < digits; i += 2 {
+		latVal += int64(digitValues[code[i]]) * pv
+		lngVal += int64(digitValues[code[i+1]]) * pv
+		if i < digits-2 {
+			pv /= encBase
+		}
+	}
+	latPrecision := float64(pv) / pairPrecision
+	lngPrecision := float64(pv) / pairPrecision
+
+	// Grid digits, in final precision units
+	gridLat, gridLng := int64(0), int64(0)
+	if len(code) > pairCodeLen {
+		rowPV, colPV := int64(gridLatFirstPlaceValue), int64(gridLngFirstPlaceValue)
+		for i := pairCodeLen; i < len(code); i++ {
+			d := int64(digitValues[code[i]])
+			gridLat += d / gridCols * rowPV
+			gridLng += d % gridCols * colPV
+			if i < len(code)-1 {
+				rowPV /= gridRows
+				colPV /= gridCols
+			}
+		}
+		latPrecision = float64(rowPV) / finalLatPrecision
+		lngPrecision = float64(colPV) / finalLngPrecision
+	}
+
+	lat := float64(latVal)/pairPrecision + float64(gridLat)/finalLatPrecision
+	lng := float64(lngVal)/pairPrecision + float64(gridLng)/finalLngPrecision
+
+	return CodeArea{
+		LatLo: lat,
+		LngLo: lng,
+		LatHi: lat + latPrecision,
+		LngHi: lng + lngPrecision,
+		Len:   len(code),
+	}, nil
+}
@@ -0,0 +1,126 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package olc
+
+import (
+	"math"
+	"strings"
+
+	"go.zenithar.org/geopoint"
+)
+
+// Encode returns the code of the given location, in degrees, with the given
+// number of digits. Valid lengths are 2, 4, 6, 8 and 10 to 15, longer lengths
+// are truncated to 15.
+func Encode(lat, lng float64, codeLen int) (string, error) {
+	if err := checkCodeLength(codeLen); err != nil {
+		return "", err
+	}
+	if math.IsNaN(lat) || math.IsInf(lat, 0) {
+		return "", geopoint.ErrNotFinite(lat)
+	}
+	if math.IsNaN(lng) || math.IsInf(lng, 0) {
+		return "", geopoint.ErrNotFinite(lng)
+	}
+
+	// Rounding to 6 decimals absorbs the floating point representation errors
+	latVal := int64(math.Round((clipLatitude(lat)+latMax)*finalLatPrecision*1e6) / 1e6)
+	lngVal := int64(math.Round((normalizeLongitude(lng)+lngMax)*finalLngPrecision*1e6) / 1e6)
+
+	return encodeIntegers(latVal, lngVal, codeLen), nil
+}
+
+// -----------------------------------------------------------------------------
+
+// checkCodeLength returns nil if the code length could be encoded.
+func checkCodeLength(codeLen int) error {
+	if codeLen < 2 || (codeLen < pairCodeLen && codeLen%2 == 1) {
+		return ErrInvalidCodeLength
+	}
+	return nil
+}
+
+// encodeIntegers returns the code of the given location, expressed in final
+// precision units from the south-west corner of the world.
+func encodeIntegers(latVal, lngVal int64, codeLen int) string {
+	if codeLen > maxCodeLen {
+		codeLen = maxCodeLen
+	}
+
+	// North pole belongs to the northernmost cell
+	const latRange, lngRange = 2 * latMax * finalLatPrecision, 2 * lngMax * finalLngPrecision
+	switch {
+	case latVal < 0:
+		latVal = 0
+	case latVal >= latRange:
+		latVal = latRange - 1
+	}
+	if lngVal %= lngRange; lngVal < 0 {
+		lngVal += lngRange
+	}
+
+	// Digits are computed from the least significant one
+	var digits [maxCodeLen]byte
+	if codeLen > pairCodeLen {
+		for i := maxCodeLen - 1; i >= pairCodeLen; i-- {
+			digits[i] = Alphabet[(latVal%gridRows)*gridCols+lngVal%gridCols]
+			latVal /= gridRows
+			lngVal /= gridCols
+		}
+	} else {
+		latVal /= finalLatPrecision / pairPrecision
+		lngVal /= finalLngPrecision / pairPrecision
+	}
+	for i := pairCodeLen/2 - 1; i >= 0; i-- {
+		digits[2*i] = Alphabet[latVal%encBase]
+		digits[2*i+1] = Alphabet[lngVal%encBase]
+		latVal /= encBase
+		lngVal /= encBase
+	}
+
+	// Insert separator and padding
+	var b strings.Builder
+	b.Grow(maxCodeLen + 1)
+	if codeLen < sepPos {
+		b.Write(digits[:codeLen])
+		b.WriteString(strings.Repeat(string(Padding), sepPos-codeLen))
+		b.WriteByte(Separator)
+	} else {
+		b.Write(digits[:sepPos])
+		b.WriteByte(Separator)
+		b.Write(digits[sepPos:codeLen])
+	}
+
+	return b.String()
+}
+
+// clipLatitude returns the latitude clipped to [-90, 90].
+func clipLatitude(lat float64) float64 {
+	return math.Min(latMax, math.Max(-latMax, lat))
+}
+
+// normalizeLongitude returns the longitude wrapped to [-180, 180[.
+func normalizeLongitude(lng float64) float64 {
+	if lng >= -lngMax && lng < lngMax {
+		return lng
+	}
+	lng = math.Mod(lng+lngMax, 2*lngMax)
+	if lng < 0 {
+		lng += 2 * lngMax
+	}
+	return lng - lngMax
+}
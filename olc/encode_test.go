@@ -0,0 +1,92 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package olc_test
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"go.zenithar.org/geopoint/olc"
+)
+
+func TestEncode(t *testing.T) {
+	for _, tc := range readTestData(t, "encoding.csv") {
+		lat, lng := parseFloat(t, tc[0]), parseFloat(t, tc[1])
+		codeLen, _ := strconv.Atoi(tc[2])
+
+		out, err := olc.Encode(lat, lng, codeLen)
+		if tc[3] == "" {
+			if err != olc.ErrInvalidCodeLength {
+				t.Fatalf("Invalid result for length %d: expected ErrInvalidCodeLength, got %v", codeLen, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unable to encode (%f, %f), got error %v", lat, lng, err)
+		}
+		if out != tc[3] {
+			t.Fatalf("Invalid result for (%v, %v, %d): expected %s, got %s", tc[0], tc[1], codeLen, tc[3], out)
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+	const epsilon = 1e-10
+
+	for _, tc := range readTestData(t, "decoding.csv") {
+		area, err := olc.Decode(tc[0])
+		if err != nil {
+			t.Fatalf("Unable to decode %q, got error %v", tc[0], err)
+		}
+
+		codeLen, _ := strconv.Atoi(tc[1])
+		if area.Len != codeLen {
+			t.Fatalf("Invalid result for %q: expected length %d, got %d", tc[0], codeLen, area.Len)
+		}
+		expected := olc.CodeArea{
+			LatLo: parseFloat(t, tc[2]),
+			LngLo: parseFloat(t, tc[3]),
+			LatHi: parseFloat(t, tc[4]),
+			LngHi: parseFloat(t, tc[5]),
+		}
+		if math.Abs(area.LatLo-expected.LatLo) > epsilon || math.Abs(area.LngLo-expected.LngLo) > epsilon ||
+			math.Abs(area.LatHi-expected.LatHi) > epsilon || math.Abs(area.LngHi-expected.LngHi) > epsilon {
+			t.Fatalf("Invalid result for %q: expected %+v, got %+v", tc[0], expected, area)
+		}
+	}
+}
+
+func TestDecode_Invalid(t *testing.T) {
+	testCases := []struct {
+		code     string
+		expected error
+	}{
+		{"", olc.ErrInvalidCode},
+		{"8FWC2345+G", olc.ErrInvalidCode},
+		{"WC2345+G6", olc.ErrNotFullCode},
+		// First digits out of range
+		{"X2222222+22", olc.ErrInvalidCode},
+		{"2X222222+22", olc.ErrInvalidCode},
+	}
+
+	for _, tc := range testCases {
+		if _, err := olc.Decode(tc.code); err != tc.expected {
+			t.Fatalf("Invalid result for %q: expected %v, got %v", tc.code, tc.expected, err)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package olc
+
+import "errors"
+
+var (
+	// ErrInvalidCode is raised when the given code is syntactically invalid
+	ErrInvalidCode = errors.New("olc: invalid code")
+	// ErrInvalidCodeLength is raised when the requested code length is not supported
+	ErrInvalidCodeLength = errors.New("olc: invalid code length")
+	// ErrNotFullCode is raised when a full code is expected
+	ErrNotFullCode = errors.New("olc: code is not a full code")
+	// ErrNotShortCode is raised when a short code is expected
+	ErrNotShortCode = errors.New("olc: code is not a short code")
+	// ErrNotShortenable is raised when the given code is padded or too short to be shortened
+	ErrNotShortenable = errors.New("olc: code cannot be shortened")
+)
@@ -0,0 +1,170 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package olc implements the Open Location Code (Plus Code) specification.
+//
+// A code is made of pairs of base 20 digits refining latitude and longitude
+// alternatively, up to 10 digits, followed by optional grid digits splitting
+// each cell in 4 columns and 5 rows. A separator is inserted after the 8th
+// digit.
+//
+//	8FM2JCV3+C5
+//
+// Short codes omit leading digits and must be recovered with a reference
+// location close to the original one.
+//
+// See https://github.com/google/open-location-code/blob/main/docs/specification.md
+package olc
+
+import "strings"
+
+const (
+	// Separator is inserted after the 8th digit of a code.
+	Separator = '+'
+	// Padding replaces the missing digits of codes shorter than 8 digits.
+	Padding = '0'
+	// Alphabet is the set of valid digits.
+	Alphabet = "23456789CFGHJMPQRVWX"
+
+	sepPos      = 8
+	encBase     = 20
+	maxCodeLen  = 15
+	pairCodeLen = 10
+	gridCodeLen = maxCodeLen - pairCodeLen
+	gridCols    = 4
+	gridRows    = 5
+
+	latMax = 90
+	lngMax = 180
+
+	// Place value of the first pair digit, in pair precision units
+	pairFirstPlaceValue = 160000 // encBase^(pairCodeLen/2-1)
+	// Number of pair precision units in a degree
+	pairPrecision = 8000 // encBase^3
+	// Place value of the first grid digit, in final precision units
+	gridLatFirstPlaceValue = 625 // gridRows^(gridCodeLen-1)
+	gridLngFirstPlaceValue = 256 // gridCols^(gridCodeLen-1)
+	// Number of final precision units in a degree
+	finalLatPrecision = pairPrecision * 3125 // gridRows^gridCodeLen
+	finalLngPrecision = pairPrecision * 1024 // gridCols^gridCodeLen
+
+	// Shortest code that could be shortened
+	minTrimmableCodeLen = 6
+)
+
+var (
+	digitValues [256]int8
+)
+
+func init() {
+	for i := range digitValues {
+		digitValues[i] = -1
+	}
+	for i := 0; i < len(Alphabet); i++ {
+		digitValues[Alphabet[i]] = int8(i)
+		digitValues[strings.ToLower(Alphabet[i : i+1])[0]] = int8(i)
+	}
+}
+
+// Check returns nil if the given code is a valid full or short code.
+func Check(code string) error {
+	// Separator is mandatory, at an even position up to sepPos
+	sep := -1
+	for i := 0; i < len(code); i++ {
+		if code[i] != Separator {
+			continue
+		}
+		if sep != -1 {
+			return ErrInvalidCode
+		}
+		sep = i
+	}
+	if sep == -1 || sep > sepPos || sep%2 == 1 {
+		return ErrInvalidCode
+	}
+
+	// Padding must be an even run just before the separator of a full code
+	if pad := strings.IndexByte(code, Padding); pad >= 0 {
+		if sep < sepPos || pad == 0 || pad%2 == 1 {
+			return ErrInvalidCode
+		}
+		if strings.TrimLeft(code[pad:], string(Padding)) != string(Separator) {
+			return ErrInvalidCode
+		}
+	}
+
+	// A single digit after the separator is not allowed
+	if len(code)-sep-1 == 1 {
+		return ErrInvalidCode
+	}
+
+	// Digits
+	digits := 0
+	for i := 0; i < len(code); i++ {
+		switch c := code[i]; {
+		case c == Separator || c == Padding:
+		case digitValues[c] < 0:
+			return ErrInvalidCode
+		default:
+			digits++
+		}
+	}
+	if digits == 0 {
+		return ErrInvalidCode
+	}
+
+	return nil
+}
+
+// CheckFull returns nil if the given code is a valid full code.
+func CheckFull(code string) error {
+	if err := Check(code); err != nil {
+		return err
+	}
+	if strings.IndexByte(code, Separator) != sepPos {
+		return ErrNotFullCode
+	}
+
+	// First digits must be in range
+	if int(digitValues[code[0]])*encBase >= 2*latMax {
+		return ErrInvalidCode
+	}
+	if int(digitValues[code[1]])*encBase >= 2*lngMax {
+		return ErrInvalidCode
+	}
+
+	return nil
+}
+
+// CheckShort returns nil if the given code is a valid short code.
+func CheckShort(code string) error {
+	if err := Check(code); err != nil {
+		return err
+	}
+	if strings.IndexByte(code, Separator) >= sepPos {
+		return ErrNotShortCode
+	}
+	return nil
+}
+
+// IsValid returns true if the given code is a valid full or short code.
+func IsValid(code string) bool { return Check(code) == nil }
+
+// IsFull returns true if the given code is a valid full code.
+func IsFull(code string) bool { return CheckFull(code) == nil }
+
+// IsShort returns true if the given code is a valid short code.
+func IsShort(code string) bool { return CheckShort(code) == nil }
@@ -0,0 +1,69 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package olc_test
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"go.zenithar.org/geopoint/olc"
+)
+
+// Test vectors are taken from the official test data
+// https://github.com/google/open-location-code/tree/main/test_data
+func readTestData(t *testing.T, name string) [][]string {
+	f, err := os.Open(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("Unable to open test data, got error %v", err)
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.Comment = '#'
+	r.FieldsPerRecord = -1
+	records, err := r.ReadAll()
+	if err != nil {
+		t.Fatalf("Unable to read test data, got error %v", err)
+	}
+	return records
+}
+
+func parseFloat(t *testing.T, s string) float64 {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		t.Fatalf("Invalid test data %q, got error %v", s, err)
+	}
+	return f
+}
+
+func TestCheck(t *testing.T) {
+	for _, tc := range readTestData(t, "validityTests.csv") {
+		code := tc[0]
+		if out := strconv.FormatBool(olc.IsValid(code)); out != tc[1] {
+			t.Fatalf("Invalid IsValid result for %q: expected %s, got %s", code, tc[1], out)
+		}
+		if out := strconv.FormatBool(olc.IsShort(code)); out != tc[2] {
+			t.Fatalf("Invalid IsShort result for %q: expected %s, got %s", code, tc[2], out)
+		}
+		if out := strconv.FormatBool(olc.IsFull(code)); out != tc[3] {
+			t.Fatalf("Invalid IsFull result for %q: expected %s, got %s", code, tc[3], out)
+		}
+	}
+}
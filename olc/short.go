@@ -0,0 +1,106 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package olc
+
+import (
+	"math"
+	"strings"
+
+	"go.zenithar.org/geopoint"
+)
+
+var (
+	// Cell size, in degrees, for each pair of digits
+	pairResolutions = [...]float64{20.0, 1.0, .05, .0025, .000125}
+)
+
+// Shorten removes as many leading digits as possible from the given full code,
+// so that it could still be recovered with the reference location.
+func Shorten(code string, ref geopoint.Value) (string, error) {
+	area, err := Decode(code)
+	if err != nil {
+		return "", err
+	}
+	if strings.IndexByte(code, Padding) >= 0 || area.Len < minTrimmableCodeLen {
+		return "", ErrNotShortenable
+	}
+
+	lat, lng, err := geopoint.Decode(ref)
+	if err != nil {
+		return "", err
+	}
+
+	// The reference must be within 0.3 of the resolution (less than the 0.5
+	// half cell, for safety) to remove a pair of digits.
+	centerLat, centerLng := area.Center()
+	distance := math.Max(math.Abs(centerLat-lat), math.Abs(centerLng-lng))
+	code = strings.ToUpper(code)
+	for i := len(pairResolutions) - 2; i >= 1; i-- {
+		if distance < pairResolutions[i]*0.3 {
+			return code[(i+1)*2:], nil
+		}
+	}
+
+	return code, nil
+}
+
+// RecoverNearest returns the full code nearest to the reference location
+// matching the given short code. Full codes are returned uppercased.
+func RecoverNearest(code string, ref geopoint.Value) (string, error) {
+	if err := CheckShort(code); err != nil {
+		if IsFull(code) {
+			return strings.ToUpper(code), nil
+		}
+		return "", err
+	}
+
+	lat, lng, err := geopoint.Decode(ref)
+	if err != nil {
+		return "", err
+	}
+
+	// Missing digits are taken from the reference
+	padLen := sepPos - strings.IndexByte(code, Separator)
+	refCode, err := Encode(lat, lng, pairCodeLen)
+	if err != nil {
+		return "", err
+	}
+	area, err := Decode(refCode[:padLen] + strings.ToUpper(code))
+	if err != nil {
+		return "", err
+	}
+
+	// Move to the neighbor cell if the reference is more than half a cell
+	// away, without crossing the poles.
+	resolution := math.Pow(encBase, float64(2-padLen/2))
+	halfRes := resolution / 2
+	centerLat, centerLng := area.Center()
+	switch {
+	case lat+halfRes < centerLat && centerLat-resolution >= -latMax:
+		centerLat -= resolution
+	case lat-halfRes > centerLat && centerLat+resolution <= latMax:
+		centerLat += resolution
+	}
+	switch {
+	case lng+halfRes < centerLng:
+		centerLng -= resolution
+	case lng-halfRes > centerLng:
+		centerLng += resolution
+	}
+
+	return Encode(centerLat, centerLng, area.Len)
+}
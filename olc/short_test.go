@@ -0,0 +1,74 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package olc_test
+
+import (
+	"testing"
+
+	"go.zenithar.org/geopoint"
+	"go.zenithar.org/geopoint/olc"
+)
+
+func TestShortCode(t *testing.T) {
+	for _, tc := range readTestData(t, "shortCodeTests.csv") {
+		code, short, mode := tc[0], tc[3], tc[4]
+		ref := geopoint.Encode(parseFloat(t, tc[1]), parseFloat(t, tc[2]))
+
+		if mode == "B" || mode == "S" {
+			out, err := olc.Shorten(code, ref)
+			if err != nil {
+				t.Fatalf("Unable to shorten %q, got error %v", code, err)
+			}
+			if out != short {
+				t.Fatalf("Invalid result for %q: expected %s, got %s", code, short, out)
+			}
+		}
+
+		if mode == "B" || mode == "R" {
+			out, err := olc.RecoverNearest(short, ref)
+			if err != nil {
+				t.Fatalf("Unable to recover %q, got error %v", short, err)
+			}
+			if out != code {
+				t.Fatalf("Invalid result for %q: expected %s, got %s", short, code, out)
+			}
+		}
+	}
+}
+
+func TestShorten_Invalid(t *testing.T) {
+	ref := geopoint.Encode(46.526, 7.976)
+	testCases := []struct {
+		code     string
+		ref      geopoint.Value
+		expected error
+	}{
+		{"G2GG+GG", ref, olc.ErrNotFullCode},
+		{"8FRCG200+", ref, olc.ErrNotShortenable},
+		{"8FRCG2GG+GG", geopoint.Empty, geopoint.ErrEmptyGeoPoint},
+	}
+
+	for _, tc := range testCases {
+		if _, err := olc.Shorten(tc.code, tc.ref); err != tc.expected {
+			t.Fatalf("Invalid result for %q: expected %v, got %v", tc.code, tc.expected, err)
+		}
+	}
+
+	if _, err := olc.RecoverNearest("G2GG+G", ref); err != olc.ErrInvalidCode {
+		t.Fatalf("Invalid result: expected ErrInvalidCode, got %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package olc
+
+import (
+	"math"
+
+	"go.zenithar.org/geopoint"
+)
+
+// FromValue returns the code of the given point with the given number of
+// digits. Micro-degrees are converted to code units without rounding. Points
+// with a precision level are located with the south-west corner of their cell.
+func FromValue(v geopoint.Value, codeLen int) (string, error) {
+	if err := checkCodeLength(codeLen); err != nil {
+		return "", err
+	}
+
+	lat, lng, err := geopoint.Decode(v)
+	if err != nil {
+		return "", err
+	}
+
+	// 1 micro-degree is 25 latitude units and 8.192 longitude units
+	latMicro := int64(math.Round(lat*1e6)) + latMax*1e6
+	lngMicro := int64(math.Round(lng*1e6)) + lngMax*1e6
+	latVal := latMicro * (finalLatPrecision / 1e6)
+	lngVal := lngMicro * finalLngPrecision / 1e6
+
+	return encodeIntegers(latVal, lngVal, codeLen), nil
+}
+
+// ToValue returns the center of the area of the given full code, rounded to
+// the micro-degree. Codes of 14 digits and more are finer than a micro-degree.
+func ToValue(code string) (geopoint.Value, error) {
+	area, err := Decode(code)
+	if err != nil {
+		return geopoint.Empty, err
+	}
+
+	return geopoint.Encode(area.Center()), nil
+}
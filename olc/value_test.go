@@ -0,0 +1,90 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package olc_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"go.zenithar.org/geopoint"
+	"go.zenithar.org/geopoint/olc"
+)
+
+func TestFromValue(t *testing.T) {
+	testCases := []struct {
+		lat, lng float64
+		codeLen  int
+		expected string
+	}{
+		{20.370113, 2.782234, 10, "7FG49QCJ+2V"},
+		{47.000062, 8.000062, 10, "8FVC2222+22"},
+		{-41.273062, 174.785937, 10, "4VCPPQGP+Q9"},
+		{90, 1, 10, "CFX3X2X2+X2"},
+		{1, 180, 4, "62H20000+"},
+		{1.2, 3.4, 10, "6FH56C22+22"},
+		{37.539669, -122.375069, 8, "849VGJQF+"},
+		{43.603574, 1.442917, 15, ""},
+	}
+
+	for _, tc := range testCases {
+		p := geopoint.Encode(tc.lat, tc.lng)
+		out, err := olc.FromValue(p, tc.codeLen)
+		if err != nil {
+			t.Fatalf("Unable to encode %d, got error %v", p, err)
+		}
+
+		// Must match the floating point encoder
+		expected, _ := olc.Encode(tc.lat, tc.lng, tc.codeLen)
+		if tc.expected != "" && expected != tc.expected {
+			t.Fatalf("Invalid test case for (%f, %f): expected %s, got %s", tc.lat, tc.lng, tc.expected, expected)
+		}
+		if out != expected {
+			t.Fatalf("Invalid result for (%f, %f): expected %s, got %s", tc.lat, tc.lng, expected, out)
+		}
+	}
+
+	if _, err := olc.FromValue(geopoint.Empty, 10); err != geopoint.ErrEmptyGeoPoint {
+		t.Fatalf("Invalid result: expected ErrEmptyGeoPoint, got %v", err)
+	}
+	if _, err := olc.FromValue(geopoint.Encode(0, 0), 9); err != olc.ErrInvalidCodeLength {
+		t.Fatalf("Invalid result: expected ErrInvalidCodeLength, got %v", err)
+	}
+}
+
+func TestToValue(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		p := geopoint.Encode(r.Float64()*180-90, r.Float64()*360-180)
+
+		// 15 digits codes are finer than a micro-degree
+		code, err := olc.FromValue(p, 15)
+		if err != nil {
+			t.Fatalf("Unable to encode %d, got error %v", p, err)
+		}
+		out, err := olc.ToValue(code)
+		if err != nil {
+			t.Fatalf("Unable to decode %q, got error %v", code, err)
+		}
+		if out != p {
+			t.Fatalf("Invalid round trip for %q, expected %d, got %d", code, p, out)
+		}
+	}
+
+	if _, err := olc.ToValue("G2GG+GG"); err != olc.ErrNotFullCode {
+		t.Fatalf("Invalid result: expected ErrNotFullCode, got %v", err)
+	}
+}
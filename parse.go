@@ -0,0 +1,416 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package geopoint
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ParseOptions tunes the coordinate parser.
+type ParseOptions struct {
+	// LonLat tells that coordinates without hemisphere letters are given
+	// longitude first.
+	LonLat bool
+	// Mode defines how out of range coordinates are handled.
+	Mode Mode
+}
+
+// Parse reads a point from a free-form coordinate string. Decimal degrees
+// (DD), degrees and decimal minutes (DDM) and degrees, minutes and seconds (DMS)
+// are supported, with signs or hemisphere letters:
+//
+//	43.603574, 1.442917
+//	43°36'12.9"N 1°26'34.5"E
+//	N43 36.215 E1 26.575
+//	-34.603722 -58.381592
+//
+// Coordinates are separated by spaces, a comma, a semicolon or a slash.
+// Hemisphere letters decide which coordinate is the latitude, otherwise it is
+// the first one unless LonLat is set.
+func Parse(s string, opts ParseOptions) (Value, error) {
+	tokens, err := tokenizeCoordinates(s)
+	if err != nil {
+		return Empty, err
+	}
+	end := len([]rune(s))
+
+	first, second, err := splitCoordinates(s, tokens, end)
+	if err != nil {
+		return Empty, err
+	}
+	a, err := parseCoordinate(s, first, second[0].pos)
+	if err != nil {
+		return Empty, err
+	}
+	b, err := parseCoordinate(s, second, end)
+	if err != nil {
+		return Empty, err
+	}
+
+	// Find the latitude
+	lat, lon := a, b
+	switch {
+	case a.hemisphere != 0 && b.hemisphere != 0:
+		if isLatitudeHemisphere(a.hemisphere) == isLatitudeHemisphere(b.hemisphere) {
+			return Empty, syntaxError(s, b.hemispherePos, "both coordinates are in the same axis")
+		}
+		if !isLatitudeHemisphere(a.hemisphere) {
+			lat, lon = b, a
+		}
+	case a.hemisphere != 0:
+		if !isLatitudeHemisphere(a.hemisphere) {
+			lat, lon = b, a
+		}
+	case b.hemisphere != 0:
+		if isLatitudeHemisphere(b.hemisphere) {
+			lat, lon = b, a
+		}
+	case opts.LonLat:
+		lat, lon = b, a
+	}
+
+	v, err := EncodeWithMode(lat.degrees, lon.degrees, opts.Mode)
+	switch err.(type) {
+	case nil:
+	case ErrLongitudeOutOfRange:
+		return Empty, syntaxError(s, lon.pos, "longitude out of range")
+	default:
+		return Empty, syntaxError(s, lat.pos, "latitude out of range")
+	}
+
+	return v, nil
+}
+
+// FormatDMS returns the point in degrees, minutes and seconds, with a tenth of
+// second precision, like 43°36'12.9"N 1°26'34.5"E.
+func (p Value) FormatDMS() string {
+	lat, lon, err := Decode(p)
+	if err != nil {
+		return ""
+	}
+
+	return formatDMS(lat, 'N', 'S') + " " + formatDMS(lon, 'E', 'W')
+}
+
+// FormatDDM returns the point in degrees and decimal minutes, with a thousandth
+// of minute precision, like 43°36.214'N 1°26.575'E.
+func (p Value) FormatDDM() string {
+	lat, lon, err := Decode(p)
+	if err != nil {
+		return ""
+	}
+
+	return formatDDM(lat, 'N', 'S') + " " + formatDDM(lon, 'E', 'W')
+}
+
+// -----------------------------------------------------------------------------
+
+type coordinateTokenKind uint8
+
+const (
+	tokenNumber coordinateTokenKind = iota
+	tokenSign
+	tokenHemisphere
+	tokenDegrees
+	tokenMinutes
+	tokenSeconds
+	tokenSeparator
+)
+
+type coordinateToken struct {
+	kind coordinateTokenKind
+	pos  int
+	// Number text, or hemisphere letter and sign character
+	text string
+}
+
+// coordinate is a parsed coordinate.
+type coordinate struct {
+	degrees       float64
+	hemisphere    byte
+	hemispherePos int
+	pos           int
+}
+
+// tokenizeCoordinates splits the given string in tokens.
+func tokenizeCoordinates(s string) ([]coordinateToken, error) {
+	runes := []rune(s)
+	tokens := make([]coordinateToken, 0, 16)
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+		case r >= '0' && r <= '9' || r == '.':
+			start := i
+			dot := false
+			for ; i < len(runes) && (runes[i] >= '0' && runes[i] <= '9' || runes[i] == '.'); i++ {
+				if runes[i] == '.' {
+					if dot {
+						return nil, syntaxError(s, i, "unexpected second decimal point")
+					}
+					dot = true
+				}
+			}
+			if i-start == 1 && dot {
+				return nil, syntaxError(s, start, "decimal point without digits")
+			}
+			tokens = append(tokens, coordinateToken{kind: tokenNumber, pos: start, text: string(runes[start:i])})
+			i--
+		case r == '+' || r == '-' || r == '−':
+			tokens = append(tokens, coordinateToken{kind: tokenSign, pos: i, text: string(r)})
+		case strings.ContainsRune("NSEWnsew", r):
+			tokens = append(tokens, coordinateToken{kind: tokenHemisphere, pos: i, text: string(unicode.ToUpper(r))})
+		case strings.ContainsRune("°º˚", r):
+			tokens = append(tokens, coordinateToken{kind: tokenDegrees, pos: i})
+		case strings.ContainsRune("'′’", r):
+			// Two single quotes are used for seconds
+			if i+1 < len(runes) && strings.ContainsRune("'′’", runes[i+1]) {
+				tokens = append(tokens, coordinateToken{kind: tokenSeconds, pos: i})
+				i++
+			} else {
+				tokens = append(tokens, coordinateToken{kind: tokenMinutes, pos: i})
+			}
+		case strings.ContainsRune("\"″”", r):
+			tokens = append(tokens, coordinateToken{kind: tokenSeconds, pos: i})
+		case strings.ContainsRune(",;/", r):
+			tokens = append(tokens, coordinateToken{kind: tokenSeparator, pos: i})
+		default:
+			return nil, syntaxError(s, i, "unexpected character "+strconv.QuoteRune(r))
+		}
+	}
+
+	return tokens, nil
+}
+
+// splitCoordinates splits tokens between both coordinates.
+func splitCoordinates(s string, tokens []coordinateToken, end int) (first, second []coordinateToken, err error) {
+	split := -1
+	numbers := 0
+	for i, t := range tokens {
+		if t.kind == tokenNumber {
+			numbers++
+		}
+		if t.kind != tokenSeparator {
+			continue
+		}
+		if split >= 0 {
+			return nil, nil, syntaxError(s, t.pos, "unexpected separator")
+		}
+		split = i
+	}
+
+	switch {
+	case split >= 0:
+		// Explicit separator
+		first, second = tokens[:split], tokens[split+1:]
+		if len(first) == 0 {
+			return nil, nil, syntaxError(s, tokens[split].pos, "missing first coordinate")
+		}
+	case len(tokens) > 0 && tokens[0].kind == tokenHemisphere:
+		// Hemisphere prefix, the second coordinate starts with a letter
+		split = len(tokens)
+		for i := 1; i < len(tokens); i++ {
+			if tokens[i].kind == tokenHemisphere {
+				split = i
+				break
+			}
+		}
+		first, second = tokens[:split], tokens[split:]
+	case hasToken(tokens, tokenHemisphere):
+		// Hemisphere suffix, the first coordinate ends with a letter
+		for i := range tokens {
+			if tokens[i].kind == tokenHemisphere {
+				split = i + 1
+				break
+			}
+		}
+		first, second = tokens[:split], tokens[split:]
+	case hasToken(tokens, tokenDegrees):
+		// The second coordinate starts with the second number marked as degrees
+		split = len(tokens)
+		seen := false
+		for i := 0; i+1 < len(tokens); i++ {
+			if tokens[i].kind != tokenNumber || tokens[i+1].kind != tokenDegrees {
+				continue
+			}
+			if seen {
+				split = i
+				if i > 0 && tokens[i-1].kind == tokenSign {
+					split--
+				}
+				break
+			}
+			seen = true
+		}
+		first, second = tokens[:split], tokens[split:]
+	case numbers < 2:
+		return nil, nil, syntaxError(s, end, "missing second coordinate")
+	case numbers == 2 || numbers == 4 || numbers == 6:
+		// Same number of components for both coordinates
+		count := 0
+		for i, t := range tokens {
+			if t.kind != tokenNumber {
+				continue
+			}
+			if count++; count == numbers/2+1 {
+				split = i
+				if i > 0 && tokens[i-1].kind == tokenSign {
+					split--
+				}
+				break
+			}
+		}
+		first, second = tokens[:split], tokens[split:]
+	default:
+		return nil, nil, syntaxError(s, tokens[len(tokens)-1].pos, "ambiguous coordinates")
+	}
+
+	if len(second) == 0 {
+		return nil, nil, syntaxError(s, end, "missing second coordinate")
+	}
+
+	return first, second, nil
+}
+
+// parseCoordinate reads a coordinate from the given tokens, end is the
+// position following the coordinate.
+func parseCoordinate(s string, tokens []coordinateToken, end int) (coordinate, error) {
+	c := coordinate{pos: tokens[0].pos}
+	negative := false
+	components := [3]float64{}
+	component := -1
+	fractional := false
+
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		switch t.kind {
+		case tokenHemisphere:
+			// Prefix or suffix only
+			if c.hemisphere != 0 || (i != 0 && i != len(tokens)-1) || (i == len(tokens)-1 && component < 0) {
+				return c, syntaxError(s, t.pos, "unexpected hemisphere letter")
+			}
+			c.hemisphere, c.hemispherePos = t.text[0], t.pos
+
+		case tokenSign:
+			if component >= 0 || negative || c.hemisphere != 0 {
+				return c, syntaxError(s, t.pos, "unexpected sign")
+			}
+			negative = t.text != "+"
+			if i+1 == len(tokens) || tokens[i+1].kind != tokenNumber {
+				return c, syntaxError(s, t.pos, "sign must be followed by a number")
+			}
+
+		case tokenNumber:
+			if fractional {
+				return c, syntaxError(s, t.pos, "only the last component may have decimals")
+			}
+
+			// Component is given by the unit mark, or follows the previous one
+			next := component + 1
+			if i+1 < len(tokens) && tokens[i+1].kind >= tokenDegrees && tokens[i+1].kind <= tokenSeconds {
+				next = int(tokens[i+1].kind - tokenDegrees)
+				i++
+			}
+			if next <= component || next > 2 {
+				return c, syntaxError(s, t.pos, "unexpected number")
+			}
+			component = next
+
+			value, err := strconv.ParseFloat(t.text, 64)
+			if err != nil {
+				return c, syntaxError(s, t.pos, "invalid number")
+			}
+			if component > 0 && value >= 60 {
+				return c, syntaxError(s, t.pos, "minutes and seconds must be lower than 60")
+			}
+			components[component] = value
+			fractional = strings.ContainsRune(t.text, '.')
+
+		default:
+			return c, syntaxError(s, t.pos, "unexpected unit mark")
+		}
+	}
+
+	if component < 0 {
+		return c, syntaxError(s, end, "missing degrees")
+	}
+
+	c.degrees = components[0] + components[1]/60 + components[2]/3600
+	if c.hemisphere == 'S' || c.hemisphere == 'W' {
+		negative = true
+	}
+	if negative {
+		c.degrees = -c.degrees
+	}
+
+	return c, nil
+}
+
+// syntaxError returns an error for the given input and position.
+func syntaxError(s string, pos int, reason string) error {
+	return ErrSyntax{Input: s, Pos: pos, Reason: reason}
+}
+
+// hasToken returns true if one of the tokens is of the given kind.
+func hasToken(tokens []coordinateToken, kind coordinateTokenKind) bool {
+	for _, t := range tokens {
+		if t.kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// isLatitudeHemisphere returns true for north and south letters.
+func isLatitudeHemisphere(h byte) bool {
+	return h == 'N' || h == 'S'
+}
+
+// formatDMS formats a coordinate in degrees, minutes and seconds.
+func formatDMS(degrees float64, positive, negative byte) string {
+	hemisphere := positive
+	if degrees < 0 {
+		hemisphere = negative
+	}
+
+	// Tenths of second, 1 micro-degree is 0.036 tenth of second
+	tenths := (int64(math.Abs(math.Round(degrees*microDegrees)))*36 + 500) / 1000
+	d, m, s := tenths/36000, tenths/600%60, tenths%600
+
+	return strconv.FormatInt(d, 10) + "°" + strconv.FormatInt(m, 10) + "'" +
+		strconv.FormatInt(s/10, 10) + "." + strconv.FormatInt(s%10, 10) + "\"" + string(hemisphere)
+}
+
+// formatDDM formats a coordinate in degrees and decimal minutes.
+func formatDDM(degrees float64, positive, negative byte) string {
+	hemisphere := positive
+	if degrees < 0 {
+		hemisphere = negative
+	}
+
+	// Thousandths of minute, 1 micro-degree is 0.06 thousandth of minute
+	thousandths := (int64(math.Abs(math.Round(degrees*microDegrees)))*6 + 50) / 100
+	d, m := thousandths/60000, thousandths%60000
+
+	frac := strconv.FormatInt(m%1000, 10)
+	return strconv.FormatInt(d, 10) + "°" + strconv.FormatInt(m/1000, 10) + "." +
+		strings.Repeat("0", 3-len(frac)) + frac + "'" + string(hemisphere)
+}
@@ -0,0 +1,152 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package geopoint_test
+
+import (
+	"math"
+	"testing"
+
+	"go.zenithar.org/geopoint"
+)
+
+func TestParse(t *testing.T) {
+	tcl := []struct {
+		name        string
+		input       string
+		opts        geopoint.ParseOptions
+		expectedLat float64
+		expectedLon float64
+	}{
+		{name: "Decimal degrees", input: "43.603574, 1.442917", expectedLat: 43.603574, expectedLon: 1.442917},
+		{name: "Decimal degrees without separator", input: "43.603574 1.442917", expectedLat: 43.603574, expectedLon: 1.442917},
+		{name: "Signed decimal degrees", input: "-34.603722;-58.381592", expectedLat: -34.603722, expectedLon: -58.381592},
+		{name: "Signed without separator", input: "-34.603722 -58.381592", expectedLat: -34.603722, expectedLon: -58.381592},
+		{name: "Longitude first", input: "1.442917 43.603574", opts: geopoint.ParseOptions{LonLat: true}, expectedLat: 43.603574, expectedLon: 1.442917},
+		{name: "DMS", input: `43°36'12.9"N 1°26'34.5"E`, expectedLat: 43.603583, expectedLon: 1.442917},
+		{name: "DMS with primes", input: "43°36′12.9″N, 1°26′34.5″E", expectedLat: 43.603583, expectedLon: 1.442917},
+		{name: "DMS with double quotes", input: "43°36'12.9''N 1°26'34.5''E", expectedLat: 43.603583, expectedLon: 1.442917},
+		{name: "DMS without hemisphere", input: `43°36'12.9" -1°26'34.5"`, expectedLat: 43.603583, expectedLon: -1.442917},
+		{name: "DMS without marks", input: "43 36 12.9 1 26 34.5", expectedLat: 43.603583, expectedLon: 1.442917},
+		{name: "DDM prefix hemisphere", input: "N43 36.215 E1 26.575", expectedLat: 43.603583, expectedLon: 1.442917},
+		{name: "DDM suffix hemisphere", input: "43 36.215N 1 26.575E", expectedLat: 43.603583, expectedLon: 1.442917},
+		{name: "Hemisphere lower case", input: "s22.9068 w43.1729", expectedLat: -22.9068, expectedLon: -43.1729},
+		{name: "Hemisphere order", input: "1.442917E 43.603574N", expectedLat: 43.603574, expectedLon: 1.442917},
+		{name: "Hemisphere overrides hint", input: "43.603574N 1.442917E", opts: geopoint.ParseOptions{LonLat: true}, expectedLat: 43.603574, expectedLon: 1.442917},
+		{name: "Single hemisphere", input: "1.442917E, 43.603574", expectedLat: 43.603574, expectedLon: 1.442917},
+		{name: "Slash", input: "48.8582/2.2945", expectedLat: 48.8582, expectedLon: 2.2945},
+		{name: "Lenient", input: "91, 181", opts: geopoint.ParseOptions{Mode: geopoint.Lenient}, expectedLat: 90, expectedLon: -179},
+	}
+
+	for _, tc := range tcl {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := geopoint.Parse(tc.input, tc.opts)
+			if err != nil {
+				t.Fatalf("Invalid result: Error not expected, got %v", err)
+			}
+			lat, lon, _ := geopoint.Decode(out)
+			if math.Abs(lat-tc.expectedLat) > 1e-6 || math.Abs(lon-tc.expectedLon) > 1e-6 {
+				t.Fatalf("Invalid result: expected %f,%f but got %f,%f", tc.expectedLat, tc.expectedLon, lat, lon)
+			}
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tcl := []struct {
+		name        string
+		input       string
+		expectedPos int
+	}{
+		{name: "Empty", input: "", expectedPos: 0},
+		{name: "Single coordinate", input: "43.6", expectedPos: 4},
+		{name: "Unexpected character", input: "43.6, 1.4x", expectedPos: 9},
+		{name: "Double decimal point", input: "43.6.1, 1.4", expectedPos: 4},
+		{name: "Too many separators", input: "43.6, 1.4, 2", expectedPos: 9},
+		{name: "Ambiguous", input: "43 36 1", expectedPos: 6},
+		{name: "Minutes overflow", input: "43 60 1 20", expectedPos: 3},
+		{name: "Seconds overflow", input: `43°36'72"N 1°26'34.5"E`, expectedPos: 6},
+		{name: "Fractional degrees with minutes", input: "43.5 36 1 26", expectedPos: 5},
+		{name: "Sign and hemisphere", input: "N-43.6 E1.4", expectedPos: 1},
+		{name: "Same axis", input: "43.6N 1.4S", expectedPos: 9},
+		{name: "Unit order", input: `43'36° 1° 2'`, expectedPos: 3},
+		{name: "Latitude out of range", input: "N95 E1", expectedPos: 0},
+		{name: "Longitude out of range", input: "43.6, 181", expectedPos: 6},
+		{name: "Not a number", input: "43.6, -", expectedPos: 6},
+	}
+
+	for _, tc := range tcl {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := geopoint.Parse(tc.input, geopoint.ParseOptions{})
+			if out != geopoint.Empty {
+				t.Fatalf("Invalid result: expected empty point, got %d", out)
+			}
+			serr, ok := err.(geopoint.ErrSyntax)
+			if !ok {
+				t.Fatalf("Invalid result: syntax error expected, got %v", err)
+			}
+			if serr.Pos != tc.expectedPos {
+				t.Fatalf("Invalid result: expected position %d, got %d (%v)", tc.expectedPos, serr.Pos, err)
+			}
+		})
+	}
+}
+
+func TestValue_FormatDMS(t *testing.T) {
+	tcl := []struct {
+		name        string
+		lat, lon    float64
+		expectedDMS string
+		expectedDDM string
+	}{
+		{name: "Toulouse", lat: 43.603583, lon: 1.442917, expectedDMS: `43°36'12.9"N 1°26'34.5"E`, expectedDDM: `43°36.215'N 1°26.575'E`},
+		{name: "Rio", lat: -22.9068, lon: -43.1729, expectedDMS: `22°54'24.5"S 43°10'22.4"W`, expectedDDM: `22°54.408'S 43°10.374'W`},
+		{name: "Zero", lat: 0, lon: 0, expectedDMS: `0°0'0.0"N 0°0'0.0"E`, expectedDDM: `0°0.000'N 0°0.000'E`},
+		{name: "Carry", lat: 10.999999, lon: -179.999999, expectedDMS: `11°0'0.0"N 180°0'0.0"W`, expectedDDM: `11°0.000'N 180°0.000'W`},
+	}
+
+	for _, tc := range tcl {
+		t.Run(tc.name, func(t *testing.T) {
+			p := geopoint.Encode(tc.lat, tc.lon)
+			if out := p.FormatDMS(); out != tc.expectedDMS {
+				t.Fatalf("Invalid result: expected %s but got %s", tc.expectedDMS, out)
+			}
+			if out := p.FormatDDM(); out != tc.expectedDDM {
+				t.Fatalf("Invalid result: expected %s but got %s", tc.expectedDDM, out)
+			}
+		})
+	}
+
+	if geopoint.Empty.FormatDMS() != "" || geopoint.Empty.FormatDDM() != "" {
+		t.Fatalf("empty point should not be formatted")
+	}
+}
+
+func TestValue_FormatDMS_RoundTrip(t *testing.T) {
+	for _, p := range randomPoints(1000) {
+		for _, s := range []string{p.FormatDMS(), p.FormatDDM()} {
+			out, err := geopoint.Parse(s, geopoint.ParseOptions{})
+			if err != nil {
+				t.Fatalf("unable to parse %s, got error %v", s, err)
+			}
+			lat, lon, _ := geopoint.Decode(p)
+			olat, olon, _ := geopoint.Decode(out)
+			if math.Abs(lat-olat) > 2e-5 || math.Abs(lon-olon) > 2e-5 {
+				t.Fatalf("invalid round trip for %s, expected %f,%f, got %f,%f", s, lat, lon, olat, olon)
+			}
+		}
+	}
+}
@@ -0,0 +1,224 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package geopoint
+
+// Ring is a closed polygon ring, its last point is linked to the first one.
+// Edges are straight lines in latitude/longitude.
+type Ring []Value
+
+// Polygon is an area bounded by an outer ring, minus its holes. Points with a
+// precision level are used as the south-west corner of their cell, polygons
+// must not cross the antimeridian.
+type Polygon struct {
+	Outer Ring
+	Holes []Ring
+}
+
+// location is the position of a point relative to a ring
+type location uint8
+
+const (
+	outside location = iota
+	boundary
+	inside
+)
+
+// Contains returns true if the point is inside the polygon or on its
+// boundary, including hole boundaries. Coordinates are compared as integer
+// micro-degrees, points on edges and vertices are always detected. Points with
+// a precision level are tested with the south-west corner of their cell.
+func (pg Polygon) Contains(p Value) bool {
+	if p == Empty {
+		return false
+	}
+
+	lat, lon := p.microDegrees()
+	return pg.contains(lat, lon)
+}
+
+// Bounds returns the bounding rectangle of the polygon outer ring
+func (pg Polygon) Bounds() Rect {
+	if len(pg.Outer) == 0 {
+		return EmptyRect()
+	}
+
+	b := pg.bounds()
+	return b.rect()
+}
+
+// Cover returns at most maxRanges sorted and disjoint ranges containing every
+// point of the polygon. Interior ranges only contain points of the polygon,
+// boundary ranges contain points that must be tested with Contains. Ranges
+// cover points without header flags, as produced by Encode. A maxRanges below 1
+// is handled as 1.
+func Cover(pg Polygon, maxRanges int) (interior, boundary []Range) {
+	if len(pg.Outer) < 3 {
+		return nil, nil
+	}
+
+	for _, r := range cover([]coverBox{pg.bounds()}, maxRanges, pg.relate, true) {
+		if r.contained {
+			interior = append(interior, r.Range)
+		} else {
+			boundary = append(boundary, r.Range)
+		}
+	}
+
+	return interior, boundary
+}
+
+// -----------------------------------------------------------------------------
+
+// microDegrees returns the point coordinates in micro-degrees, rebased on the
+// south pole and the antimeridian.
+func (p Value) microDegrees() (int64, int64) {
+	value := uint64(p.origin())
+
+	highLat := int64((value >> 49) & 0xFF)
+	highLon := int64((value >> 40) & 0x1FF)
+	lowLat, lowLon := deinterleave(value & 0xFFFFFFFFFF)
+
+	return highLat*microDegrees + int64(lowLat), highLon*microDegrees + int64(lowLon)
+}
+
+// contains returns true if the given rebased micro-degrees are inside the
+// polygon or on its boundary.
+func (pg Polygon) contains(lat, lon int64) bool {
+	switch pg.Outer.locate(lat, lon) {
+	case outside:
+		return false
+	case boundary:
+		return true
+	}
+
+	for _, h := range pg.Holes {
+		if h.locate(lat, lon) == inside {
+			return false
+		}
+	}
+
+	return true
+}
+
+// bounds returns the bounding box of the outer ring
+func (pg Polygon) bounds() coverBox {
+	lat, lon := pg.Outer[0].microDegrees()
+	b := coverBox{minLat: lat, minLon: lon, maxLat: lat, maxLon: lon}
+	for _, v := range pg.Outer[1:] {
+		lat, lon := v.microDegrees()
+		b.minLat, b.maxLat = min64(b.minLat, lat), max64(b.maxLat, lat)
+		b.minLon, b.maxLon = min64(b.minLon, lon), max64(b.maxLon, lon)
+	}
+
+	return b
+}
+
+// relate returns whether the box intersects the polygon, and whether it is
+// contained by the polygon.
+func (pg Polygon) relate(b coverBox) (bool, bool) {
+	// Boxes crossed by an edge are partially covered
+	for _, r := range append([]Ring{pg.Outer}, pg.Holes...) {
+		for i := range r {
+			aLat, aLon := r[i].microDegrees()
+			bLat, bLon := r[(i+1)%len(r)].microDegrees()
+			if segmentIntersectsBox(aLat, aLon, bLat, bLon, b) {
+				return true, false
+			}
+		}
+	}
+
+	// Otherwise every point of the box is on the same side
+	in := pg.contains(b.minLat, b.minLon)
+	return in, in
+}
+
+// locate returns the location of the given rebased micro-degrees relative to
+// the ring, using the crossing number of a ray cast eastward.
+func (r Ring) locate(lat, lon int64) location {
+	if len(r) < 3 {
+		return outside
+	}
+
+	in := false
+	aLat, aLon := r[len(r)-1].microDegrees()
+	for _, v := range r {
+		bLat, bLon := v.microDegrees()
+
+		// Point on the edge
+		if (bLat-aLat)*(lon-aLon) == (bLon-aLon)*(lat-aLat) &&
+			lat >= min64(aLat, bLat) && lat <= max64(aLat, bLat) &&
+			lon >= min64(aLon, bLon) && lon <= max64(aLon, bLon) {
+			return boundary
+		}
+
+		// Edge crosses the ray, east of the point
+		if (aLat > lat) != (bLat > lat) {
+			cross := (aLon-lon)*(bLat-aLat) + (lat-aLat)*(bLon-aLon)
+			if (cross > 0) == (bLat > aLat) {
+				in = !in
+			}
+		}
+
+		aLat, aLon = bLat, bLon
+	}
+
+	if in {
+		return inside
+	}
+	return outside
+}
+
+// segmentIntersectsBox returns true if the segment has a common point with the
+// box: bounding boxes overlap and box corners are not all on the same side of
+// the segment line.
+func segmentIntersectsBox(aLat, aLon, bLat, bLon int64, b coverBox) bool {
+	if max64(aLat, bLat) < b.minLat || min64(aLat, bLat) > b.maxLat ||
+		max64(aLon, bLon) < b.minLon || min64(aLon, bLon) > b.maxLon {
+		return false
+	}
+
+	positive, negative := false, false
+	for _, c := range [4][2]int64{
+		{b.minLat, b.minLon}, {b.minLat, b.maxLon},
+		{b.maxLat, b.minLon}, {b.maxLat, b.maxLon},
+	} {
+		switch side := (bLat-aLat)*(c[1]-aLon) - (bLon-aLon)*(c[0]-aLat); {
+		case side > 0:
+			positive = true
+		case side < 0:
+			negative = true
+		default:
+			return true
+		}
+	}
+
+	return positive && negative
+}
+
+func min64(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,166 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package geopoint_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"go.zenithar.org/geopoint"
+)
+
+func ring(coords ...float64) geopoint.Ring {
+	r := make(geopoint.Ring, 0, len(coords)/2)
+	for i := 0; i+1 < len(coords); i += 2 {
+		r = append(r, geopoint.Encode(coords[i], coords[i+1]))
+	}
+	return r
+}
+
+func TestPolygon_Contains(t *testing.T) {
+	// Square with a square hole
+	square := geopoint.Polygon{
+		Outer: ring(0, 0, 0, 10, 10, 10, 10, 0),
+		Holes: []geopoint.Ring{ring(4, 4, 6, 4, 6, 6, 4, 6)},
+	}
+	// Diamond, vertices are on the ray of some points
+	diamond := geopoint.Polygon{
+		Outer: ring(0, 5, 5, 10, 10, 5, 5, 0),
+	}
+	// Concave L shape
+	l := geopoint.Polygon{
+		Outer: ring(0, 0, 0, 2, 1, 2, 1, 1, 2, 1, 2, 0),
+	}
+
+	tcl := []struct {
+		name     string
+		polygon  geopoint.Polygon
+		lat, lon float64
+		expected bool
+	}{
+		{name: "Inside", polygon: square, lat: 2, lon: 2, expected: true},
+		{name: "Outside", polygon: square, lat: 12, lon: 2},
+		{name: "Outer edge", polygon: square, lat: 0, lon: 5, expected: true},
+		{name: "Outer vertex", polygon: square, lat: 10, lon: 10, expected: true},
+		{name: "Outer edge micro-degree outside", polygon: square, lat: 10.000001, lon: 5},
+		{name: "Hole", polygon: square, lat: 5, lon: 5},
+		{name: "Hole edge", polygon: square, lat: 4, lon: 5, expected: true},
+		{name: "Hole vertex", polygon: square, lat: 6, lon: 6, expected: true},
+		{name: "Between hole and edge", polygon: square, lat: 5, lon: 2, expected: true},
+		{name: "Ray through vertex inside", polygon: diamond, lat: 5, lon: 5, expected: true},
+		{name: "Ray through vertex outside", polygon: diamond, lat: 5, lon: -1},
+		{name: "Ray through top vertex", polygon: diamond, lat: 10, lon: 1},
+		{name: "Diamond vertex", polygon: diamond, lat: 10, lon: 5, expected: true},
+		{name: "Diamond edge", polygon: diamond, lat: 2.5, lon: 7.5, expected: true},
+		{name: "Diamond edge outside", polygon: diamond, lat: 2.5, lon: 7.500001},
+		{name: "Concave inside", polygon: l, lat: 0.5, lon: 1.5, expected: true},
+		{name: "Concave notch", polygon: l, lat: 1.5, lon: 1.5},
+		{name: "Concave reflex vertex", polygon: l, lat: 1, lon: 1, expected: true},
+		{name: "Degenerate ring", polygon: geopoint.Polygon{Outer: ring(0, 0, 1, 1)}, lat: 0.5, lon: 0.5},
+	}
+
+	for _, tc := range tcl {
+		t.Run(tc.name, func(t *testing.T) {
+			if out := tc.polygon.Contains(geopoint.Encode(tc.lat, tc.lon)); out != tc.expected {
+				t.Fatalf("Invalid result: expected %v but got %v", tc.expected, out)
+			}
+		})
+	}
+
+	if square.Contains(geopoint.Empty) {
+		t.Fatalf("Invalid result: empty point should not be contained")
+	}
+}
+
+func TestPolygon_Bounds(t *testing.T) {
+	pg := geopoint.Polygon{Outer: ring(43.5, 1.3, 43.7, 1.4, 43.6, 1.6)}
+	expected := geopoint.Rect{MinLat: 43.5, MinLon: 1.3, MaxLat: 43.7, MaxLon: 1.6}
+	if out := pg.Bounds(); out != expected {
+		t.Fatalf("Invalid result: expected %v but got %v", expected, out)
+	}
+	if !(geopoint.Polygon{}).Bounds().IsEmpty() {
+		t.Fatalf("Invalid result: polygon without ring should have empty bounds")
+	}
+}
+
+func TestCover_BruteForce(t *testing.T) {
+	polygons := []geopoint.Polygon{
+		{
+			Outer: ring(43.55, 1.35, 43.56, 1.52, 43.66, 1.55, 43.64, 1.45, 43.68, 1.36),
+			Holes: []geopoint.Ring{ring(43.59, 1.42, 43.6, 1.46, 43.62, 1.43)},
+		},
+		{Outer: ring(-10, -20, 30, -15, 5, 0, 30, 15, -10, 20)},
+		{Outer: ring(0, 0, 0.000010, 0.000003, 0.000002, 0.000012)},
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for _, pg := range polygons {
+		bounds := pg.Bounds()
+		points := make([]geopoint.Value, 20000)
+		for i := range points {
+			points[i] = geopoint.Encode(
+				bounds.MinLat+(r.Float64()*1.2-0.1)*(bounds.MaxLat-bounds.MinLat),
+				bounds.MinLon+(r.Float64()*1.2-0.1)*(bounds.MaxLon-bounds.MinLon),
+			)
+		}
+
+		for _, maxRanges := range []int{1, 8, 64} {
+			interior, boundary := geopoint.Cover(pg, maxRanges)
+			if len(interior)+len(boundary) > maxRanges || len(boundary) == 0 {
+				t.Fatalf("Invalid result: expected at most %d ranges, got %d and %d", maxRanges, len(interior), len(boundary))
+			}
+
+			for _, p := range points {
+				inInterior, inBoundary := false, false
+				for _, rg := range interior {
+					inInterior = inInterior || rg.Contains(p)
+				}
+				for _, rg := range boundary {
+					inBoundary = inBoundary || rg.Contains(p)
+				}
+
+				switch contained := pg.Contains(p); {
+				case inInterior && inBoundary:
+					t.Fatalf("Invalid result: ranges should be disjoint for %f", p)
+				case inInterior && !contained:
+					t.Fatalf("Invalid result: %f is in an interior range, but not in the polygon", p)
+				case contained && !inInterior && !inBoundary:
+					t.Fatalf("Invalid result: %f should be covered", p)
+				}
+			}
+		}
+	}
+}
+
+func TestCover_Interior(t *testing.T) {
+	pg := geopoint.Polygon{Outer: ring(43.55, 1.35, 43.56, 1.52, 43.66, 1.55, 43.64, 1.45, 43.68, 1.36)}
+
+	interior, _ := geopoint.Cover(pg, 64)
+	if len(interior) == 0 {
+		t.Fatalf("Invalid result: expected interior ranges")
+	}
+	if interior, boundary := geopoint.Cover(geopoint.Polygon{}, 64); interior != nil || boundary != nil {
+		t.Fatalf("Invalid result: polygon without ring should have no cover")
+	}
+
+	// Below 1, a single boundary range is returned
+	for _, maxRanges := range []int{0, -1} {
+		if interior, boundary := geopoint.Cover(pg, maxRanges); len(interior) != 0 || len(boundary) != 1 {
+			t.Fatalf("Invalid result for %d ranges: expected a single boundary range, got %v and %v", maxRanges, interior, boundary)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package geopoint
+
+import (
+	"math/bits"
+)
+
+// Level defines the precision of a point, as the number of fractional bits
+// kept on each axis. A point at level L stands for a cell of 2^(20-L)
+// micro-degrees on both axis.
+type Level uint8
+
+const (
+	// LevelDegree keeps integer degrees only (~111km)
+	LevelDegree Level = 0
+	// LevelMax keeps every micro-degree (~11cm)
+	LevelMax Level = 20
+)
+
+const (
+	// precisionFlag is set when the point carries a precision level marker.
+	// The level is encoded in the interleaved part as its lowest set bit, just
+	// above the dropped bits (same trick as S2 cell identifiers).
+	precisionFlag = uint64(FlagPrecision) << headerShift
+	// metersPerDegree is the length of a latitude degree on a spherical earth
+	metersPerDegree = 111319.49
+)
+
+// Size returns the approximate height of a cell at this level in meters
+func (l Level) Size() float64 {
+	if l > LevelMax {
+		l = LevelMax
+	}
+
+	// Cells never span more than a degree
+	size := uint64(1) << (LevelMax - l)
+	if size > microDegrees {
+		size = microDegrees
+	}
+
+	return float64(size) / microDegrees * metersPerDegree
+}
+
+// LevelForSize returns the coarsest level whose cells are not higher than the
+// given size in meters.
+func LevelForSize(meters float64) Level {
+	for l := LevelDegree; l < LevelMax; l++ {
+		if l.Size() <= meters {
+			return l
+		}
+	}
+	return LevelMax
+}
+
+// EncodeWithPrecision encodes a point at the given level
+func EncodeWithPrecision(latitude float64, longitude float64, level Level) Value {
+	return Encode(latitude, longitude).truncate(level)
+}
+
+// Precision returns the precision level of the point
+func (p Value) Precision() Level {
+	value := uint64(p)
+	if value&precisionFlag == 0 {
+		return LevelMax
+	}
+
+	// Locate level marker
+	marker := bits.TrailingZeros64(value & 0xFFFFFFFFFF)
+	if marker > 39 {
+		return LevelDegree
+	}
+
+	return Level((39 - marker) / 2)
+}
+
+// Truncate returns the point truncated to the given level. Dropped interleaved
+// bits are zeroed so that the payload of the truncated point is a prefix of the
+// original one, the highest dropped bit is then set as a level marker. The
+// point is flagged as truncated, unless the given level is not coarser than the
+// current one; the point is then returned unchanged. The precision flag orders
+// truncated points after every full precision one, use PrefixRange to scan the
+// full precision points of a cell.
+func (p Value) Truncate(level Level) Value {
+	if p == Empty || level >= p.Precision() {
+		return p
+	}
+	return p.truncate(level).WithFlags(FlagTruncated)
+}
+
+func (p Value) truncate(level Level) Value {
+	if level >= p.Precision() {
+		return p
+	}
+
+	// Remove previous marker
+	value := uint64(p.origin())
+
+	// Zero dropped bits and set level marker
+	dropped := 2 * uint(LevelMax-level)
+	value = value >> dropped << dropped
+	value |= uint64(1) << (dropped - 1)
+
+	return Value(value | precisionFlag)
+}
+
+// PrefixRange returns the bounds of the full precision points sharing the
+// point prefix: a full precision point with the same anonymized flag lies in
+// [lo, hi] if and only if it belongs to the point cell. The empty point has
+// empty bounds.
+func (p Value) PrefixRange() (lo, hi Value) {
+	if p == Empty {
+		return Empty, Empty
+	}
+
+	lo = Value(uint64(p.origin()) & payloadMask).WithFlags(p.Flags() & FlagAnonymized)
+	dropped := 2 * uint(LevelMax-p.Precision())
+	return lo, lo | Value(uint64(1)<<dropped-1)
+}
+
+// origin returns the full precision point at the south-west corner of the
+// cell, without precision flag and level marker.
+func (p Value) origin() Value {
+	value := uint64(p)
+	if value&precisionFlag == 0 {
+		return p
+	}
+
+	// Clear lowest set bit of the interleaved part
+	low := value & 0xFFFFFFFFFF
+	value = value &^ precisionFlag &^ (low & -low)
+
+	return Value(value)
+}
+
+// codeDigits returns the count of significant hexadecimal digits of the
+// interleaved part.
+func (p Value) codeDigits() int {
+	level := p.Precision()
+	if level == LevelMax {
+		return 10
+	}
+	return int(level)/2 + 1
+}
+
+// Bounds returns the rectangle covered by the point cell. A full precision
+// point stands for a 1µ° x 1µ° cell, cells never span more than a degree. The
+// empty point has empty bounds.
+func (p Value) Bounds() Rect {
+	if p == Empty {
+		return EmptyRect()
+	}
+
+	minLat, minLon, maxLat, maxLon := p.cellBounds()
+	return Rect{
+		MinLat: fromMicroDegrees(minLat - 90*microDegrees),
+		MinLon: fromMicroDegrees(minLon - 180*microDegrees),
+		MaxLat: fromMicroDegrees(maxLat - 90*microDegrees),
+		MaxLon: fromMicroDegrees(maxLon - 180*microDegrees),
+	}
+}
+
+// Center returns the full precision point at the center of the point cell,
+// rounded to the south-west micro-degree. The anonymized flag is kept.
+func (p Value) Center() Value {
+	if p == Empty {
+		return Empty
+	}
+
+	minLat, minLon, maxLat, maxLon := p.cellBounds()
+	center := Encode(
+		fromMicroDegrees((minLat+maxLat)/2-90*microDegrees),
+		fromMicroDegrees((minLon+maxLon)/2-180*microDegrees),
+	)
+
+	return center.WithFlags(p.Flags() & FlagAnonymized)
+}
+
+// cellBounds returns the point cell bounds in micro-degrees, rebased on the
+// south pole and the antimeridian.
+func (p Value) cellBounds() (minLat, minLon, maxLat, maxLon int64) {
+	value := uint64(p.origin())
+
+	highLat := int64((value >> 49) & 0xFF)
+	highLon := int64((value >> 40) & 0x1FF)
+	lowLat, lowLon := deinterleave(value & 0xFFFFFFFFFF)
+
+	// Cells are clipped to the degree
+	size := int64(1) << (LevelMax - p.Precision())
+	height, width := size, size
+	if rest := microDegrees - int64(lowLat); rest < height {
+		height = rest
+	}
+	if rest := microDegrees - int64(lowLon); rest < width {
+		width = rest
+	}
+
+	minLat = highLat*microDegrees + int64(lowLat)
+	minLon = highLon*microDegrees + int64(lowLon)
+	maxLat = minLat + height
+	if maxLat > 180*microDegrees {
+		maxLat = 180 * microDegrees
+	}
+
+	return minLat, minLon, maxLat, minLon + width
+}
@@ -0,0 +1,241 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package geopoint_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"go.zenithar.org/geopoint"
+)
+
+func TestValue_Truncate(t *testing.T) {
+	p := geopoint.Encode(43.603574, 1.442917)
+
+	tcl := []struct {
+		level        geopoint.Level
+		expectedCode string
+		expectedLat  float64
+		expectedLon  float64
+	}{
+		{level: 0, expectedCode: "70AB5:8", expectedLat: 43, expectedLon: 1},
+		{level: 1, expectedCode: "70AB5:6", expectedLat: 43.524288, expectedLon: 1},
+		{level: 2, expectedCode: "70AB5:68", expectedLat: 43.524288, expectedLon: 1.262144},
+		{level: 10, expectedCode: "70AB5:69A51:8", expectedLat: 43.603136, expectedLon: 1.442368},
+		{level: 11, expectedCode: "70AB5:69A51:A", expectedLat: 43.603136, expectedLon: 1.44288},
+		{level: 19, expectedCode: "70AB5:69A51:94D36", expectedLat: 43.603574, expectedLon: 1.442916},
+		{level: 20, expectedCode: "10AB5:69A51:94D36", expectedLat: 43.603574, expectedLon: 1.442917},
+	}
+
+	for _, tc := range tcl {
+		t.Run(tc.expectedCode, func(t *testing.T) {
+			out := p.Truncate(tc.level)
+			if out.Precision() != tc.level {
+				t.Fatalf("invalid precision, expected %d, got %d", tc.level, out.Precision())
+			}
+			if out.Code() != tc.expectedCode {
+				t.Fatalf("invalid code, expected %s, got %s", tc.expectedCode, out.Code())
+			}
+			expected := geopoint.EncodeWithPrecision(43.603574, 1.442917, tc.level)
+			if tc.level < geopoint.LevelMax {
+				if expected.Flags() != geopoint.FlagPrecision {
+					t.Fatalf("invalid flags, expected %d, got %d", geopoint.FlagPrecision, expected.Flags())
+				}
+				expected = expected.WithFlags(geopoint.FlagTruncated)
+			}
+			if expected != out {
+				t.Fatalf("invalid encoding with precision, expected %d, got %d", expected, out)
+			}
+
+			lat, lon, err := geopoint.Decode(out)
+			if err != nil {
+				t.Fatalf("error should not be raised, got %v", err)
+			}
+			if lat != tc.expectedLat || lon != tc.expectedLon {
+				t.Fatalf("invalid cell origin, expected (%v,%v), got (%v,%v)", tc.expectedLat, tc.expectedLon, lat, lon)
+			}
+
+			parsed, err := geopoint.ParseCode(tc.expectedCode)
+			if err != nil {
+				t.Fatalf("error should not be raised, got %v", err)
+			}
+			if parsed != out {
+				t.Fatalf("invalid parsed point, expected %d, got %d", out, parsed)
+			}
+		})
+	}
+}
+
+func TestValue_Truncate_Prefix(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		p := geopoint.Encode(r.Float64()*180-90, r.Float64()*360-180)
+
+		prev := p
+		for level := geopoint.LevelMax; ; level-- {
+			out := p.Truncate(level)
+			if out.Precision() != level {
+				t.Fatalf("invalid precision for %d, expected %d, got %d", p, level, out.Precision())
+			}
+
+			// Kept bits must be shared with the original point and with the
+			// next finer level.
+			kept := uint(40 - 2*int(level))
+			payload := uint64(1)<<57 - 1
+			if uint64(out)&payload>>kept != uint64(p)>>kept {
+				t.Fatalf("truncated point %d is not a prefix of %d at level %d", out, p, level)
+			}
+			if out.Truncate(level) != out || prev.Truncate(level) != out {
+				t.Fatalf("truncation is not stable for %d at level %d", p, level)
+			}
+			if _, err := geopoint.ParseCode(out.Code()); err != nil {
+				t.Fatalf("unable to parse %s, got error %v", out.Code(), err)
+			}
+
+			prev = out
+			if level == geopoint.LevelDegree {
+				break
+			}
+		}
+	}
+}
+
+func TestValue_PrefixRange(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		p := geopoint.Encode(r.Float64()*180-90, r.Float64()*360-180)
+		if i%4 == 0 {
+			p = p.WithFlags(geopoint.FlagAnonymized)
+		}
+		level := geopoint.Level(r.Intn(21))
+		cell := p.Truncate(level)
+
+		lo, hi := cell.PrefixRange()
+		if p < lo || p > hi {
+			t.Fatalf("Invalid result: %d should be in [%d, %d] at level %d", p, lo, hi, level)
+		}
+
+		// Close points are in the range only when they share the cell
+		q := geopoint.Encode(r.Float64()*180-90, r.Float64()*360-180)
+		if i%2 == 0 {
+			q = geopoint.Value(uint64(p) ^ uint64(r.Intn(1<<12)))
+		}
+		if inRange := q >= lo && q <= hi; inRange != (q.Truncate(level) == cell) {
+			t.Fatalf("Invalid result: %d in [%d, %d] is %t at level %d", q, lo, hi, inRange, level)
+		}
+	}
+
+	if lo, hi := geopoint.Empty.PrefixRange(); lo != geopoint.Empty || hi != geopoint.Empty {
+		t.Fatalf("Invalid result: empty point should have empty bounds, got [%d, %d]", lo, hi)
+	}
+}
+
+func TestParseCode_Precision(t *testing.T) {
+	tcl := []struct {
+		name        string
+		input       string
+		expectedErr error
+	}{
+		{name: "Short code without precision", input: "10AB5:8", expectedErr: geopoint.ErrInvalidGeoPointValue},
+		{name: "Marker on latitude bit", input: "30AB5:4", expectedErr: geopoint.ErrInvalidGeoPointValue},
+		{name: "Missing marker", input: "30AB5:0", expectedErr: geopoint.ErrInvalidGeoPointValue},
+		{name: "Non significant digits", input: "30AB5:80", expectedErr: geopoint.ErrInvalidGeoPointValue},
+		{name: "Degree cell", input: "30AB5:8"},
+		{name: "Lowercase", input: "30ab5:69a51:a"},
+	}
+
+	for _, tc := range tcl {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := geopoint.ParseCode(tc.input); err != tc.expectedErr {
+				t.Fatalf("Invalid result: Error expected %v, got %v.", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestLevelForSize(t *testing.T) {
+	tcl := []struct {
+		meters        float64
+		expectedLevel geopoint.Level
+	}{
+		{meters: 1000000, expectedLevel: geopoint.LevelDegree},
+		{meters: 111319.49, expectedLevel: geopoint.LevelDegree},
+		{meters: 100000, expectedLevel: 1},
+		{meters: 100, expectedLevel: 11},
+		{meters: 1, expectedLevel: 17},
+		{meters: 0.01, expectedLevel: geopoint.LevelMax},
+	}
+
+	for _, tc := range tcl {
+		if level := geopoint.LevelForSize(tc.meters); level != tc.expectedLevel {
+			t.Errorf("invalid level for %vm, expected %d, got %d", tc.meters, tc.expectedLevel, level)
+		}
+		if level := geopoint.LevelForSize(tc.meters); level.Size() > tc.meters && level != geopoint.LevelMax {
+			t.Errorf("cell size %vm is larger than %vm", level.Size(), tc.meters)
+		}
+	}
+}
+
+func TestValue_Bounds(t *testing.T) {
+	p := geopoint.Encode(43.603574, 1.442917)
+
+	tcl := []struct {
+		name           string
+		value          geopoint.Value
+		expectedBounds geopoint.Rect
+		expectedCenter geopoint.Value
+	}{
+		{name: "Full precision", value: p, expectedBounds: geopoint.Rect{MinLat: 43.603574, MinLon: 1.442917, MaxLat: 43.603575, MaxLon: 1.442918}, expectedCenter: p},
+		{name: "Level 10", value: p.Truncate(10), expectedBounds: geopoint.Rect{MinLat: 43.603136, MinLon: 1.442368, MaxLat: 43.60416, MaxLon: 1.443392}, expectedCenter: geopoint.Encode(43.603648, 1.44288)},
+		{name: "Clipped to degree", value: p.Truncate(1), expectedBounds: geopoint.Rect{MinLat: 43.524288, MinLon: 1, MaxLat: 44, MaxLon: 1.524288}, expectedCenter: geopoint.Encode(43.762144, 1.262144)},
+		{name: "Degree", value: p.Truncate(0), expectedBounds: geopoint.Rect{MinLat: 43, MinLon: 1, MaxLat: 44, MaxLon: 2}, expectedCenter: geopoint.Encode(43.5, 1.5)},
+		{name: "North pole", value: geopoint.EncodeWithPrecision(90, 0, 5), expectedBounds: geopoint.Rect{MinLat: 90, MinLon: 0, MaxLat: 90, MaxLon: 0.032768}, expectedCenter: geopoint.Encode(90, 0.016384)},
+		{name: "Antimeridian", value: geopoint.EncodeWithPrecision(-0.5, 179.9999, 0), expectedBounds: geopoint.Rect{MinLat: -1, MinLon: 179, MaxLat: 0, MaxLon: 180}, expectedCenter: geopoint.Encode(-0.5, 179.5)},
+		{name: "Anonymized", value: p.WithFlags(geopoint.FlagAnonymized).Truncate(0), expectedBounds: geopoint.Rect{MinLat: 43, MinLon: 1, MaxLat: 44, MaxLon: 2}, expectedCenter: geopoint.Encode(43.5, 1.5).WithFlags(geopoint.FlagAnonymized)},
+	}
+
+	for _, tc := range tcl {
+		t.Run(tc.name, func(t *testing.T) {
+			if out := tc.value.Bounds(); out != tc.expectedBounds {
+				t.Fatalf("Invalid result: expected %v but got %v", tc.expectedBounds, out)
+			}
+			if out := tc.value.Center(); out != tc.expectedCenter {
+				t.Fatalf("Invalid result: expected %+v but got %+v", tc.expectedCenter, out)
+			}
+		})
+	}
+
+	if !geopoint.Empty.Bounds().IsEmpty() || geopoint.Empty.Center() != geopoint.Empty {
+		t.Fatalf("Invalid result: empty point should have empty bounds")
+	}
+}
+
+func TestValue_Bounds_Contains(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		p := geopoint.Encode(r.Float64()*180-90, r.Float64()*360-180)
+		cell := p.Truncate(geopoint.Level(r.Intn(21)))
+
+		bounds := cell.Bounds()
+		if !bounds.Contains(p) || !bounds.Contains(cell) || !bounds.Contains(cell.Center()) {
+			t.Fatalf("Invalid result: %v should contain %f and its center", bounds, p)
+		}
+		if cell.Center().Truncate(cell.Precision()) != cell && cell != p {
+			t.Fatalf("Invalid result: center of %s should be in the same cell, got %s", cell, cell.Center())
+		}
+	}
+}
@@ -0,0 +1,220 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package geopoint
+
+import "math"
+
+// earthRadius is the mean earth radius in meters
+const earthRadius = 6371008.8
+
+// Rect is a latitude/longitude rectangle, expressed in degrees. A rectangle
+// whose MinLon is greater than its MaxLon crosses the antimeridian, a
+// rectangle whose MinLat is greater than its MaxLat is empty.
+type Rect struct {
+	MinLat, MinLon float64
+	MaxLat, MaxLon float64
+}
+
+// EmptyRect returns a rectangle that contains nothing
+func EmptyRect() Rect {
+	return Rect{MinLat: 1, MinLon: 180, MaxLat: -1, MaxLon: -180}
+}
+
+// FullRect returns a rectangle that contains every point
+func FullRect() Rect {
+	return Rect{MinLat: -90, MinLon: -180, MaxLat: 90, MaxLon: 180}
+}
+
+// IsEmpty returns true if the rectangle contains nothing
+func (r Rect) IsEmpty() bool {
+	return !(r.MinLat <= r.MaxLat)
+}
+
+// Contains returns true if the given point is inside the rectangle, edges
+// included. Points with a precision level are tested with the south-west
+// corner of their cell.
+func (r Rect) Contains(p Value) bool {
+	lat, lon, err := Decode(p)
+	if err != nil {
+		return false
+	}
+	return r.containsLatLon(lat, lon)
+}
+
+// Intersects returns true if both rectangles have at least a common point
+func (r Rect) Intersects(other Rect) bool {
+	if r.IsEmpty() || other.IsEmpty() {
+		return false
+	}
+	if r.MinLat > other.MaxLat || other.MinLat > r.MaxLat {
+		return false
+	}
+
+	switch {
+	case r.crossesAntimeridian() && other.crossesAntimeridian():
+		return true
+	case r.crossesAntimeridian():
+		return other.MinLon <= r.MaxLon || other.MaxLon >= r.MinLon
+	case other.crossesAntimeridian():
+		return r.MinLon <= other.MaxLon || r.MaxLon >= other.MinLon
+	default:
+		return r.MinLon <= other.MaxLon && other.MinLon <= r.MaxLon
+	}
+}
+
+// Union returns the smallest rectangle containing both rectangles. Disjoint
+// longitude ranges are joined through the shortest gap, which may cross the
+// antimeridian.
+func (r Rect) Union(other Rect) Rect {
+	switch {
+	case r.IsEmpty():
+		return other
+	case other.IsEmpty():
+		return r
+	}
+
+	out := Rect{
+		MinLat: math.Min(r.MinLat, other.MinLat),
+		MaxLat: math.Max(r.MaxLat, other.MaxLat),
+	}
+	out.MinLon, out.MaxLon = lonUnion(r.MinLon, r.MaxLon, other.MinLon, other.MaxLon)
+
+	return out
+}
+
+// Expand returns the rectangle grown by the given distance in meters on each
+// side. Latitudes are clamped at the poles, the rectangle spans every
+// longitude when a grown side reaches a pole.
+func (r Rect) Expand(meters float64) Rect {
+	if r.IsEmpty() || !(meters > 0) {
+		return r
+	}
+
+	angle := meters / earthRadius
+	margin := angle * 180 / math.Pi
+
+	out := Rect{
+		MinLat: math.Max(-90, r.MinLat-margin),
+		MaxLat: math.Min(90, r.MaxLat+margin),
+	}
+
+	// Widest longitude extent of a circle centered on the most poleward latitude
+	lat := math.Max(math.Abs(r.MinLat), math.Abs(r.MaxLat)) * math.Pi / 180
+	if out.MinLat == -90 || out.MaxLat == 90 || math.Sin(angle) >= math.Cos(lat) {
+		out.MinLon, out.MaxLon = -180, 180
+		return out
+	}
+	lonMargin := math.Asin(math.Sin(angle)/math.Cos(lat)) * 180 / math.Pi
+
+	// Full longitude range once both sides meet
+	width := r.MaxLon - r.MinLon
+	if r.crossesAntimeridian() {
+		width += 360
+	}
+	if width+2*lonMargin >= 360 {
+		out.MinLon, out.MaxLon = -180, 180
+		return out
+	}
+
+	out.MinLon, out.MaxLon = r.MinLon-lonMargin, r.MaxLon+lonMargin
+	if out.MinLon < -180 {
+		out.MinLon += 360
+	}
+	if out.MaxLon > 180 {
+		out.MaxLon -= 360
+	}
+
+	return out
+}
+
+// -----------------------------------------------------------------------------
+
+// crossesAntimeridian returns true if the rectangle longitude range wraps
+// around ±180.
+func (r Rect) crossesAntimeridian() bool {
+	return r.MinLon > r.MaxLon
+}
+
+// containsLatLon returns true if the given coordinates are inside the
+// rectangle, edges included.
+func (r Rect) containsLatLon(lat, lon float64) bool {
+	if lat < r.MinLat || lat > r.MaxLat {
+		return false
+	}
+	if r.crossesAntimeridian() {
+		return lon >= r.MinLon || lon <= r.MaxLon
+	}
+	return lon >= r.MinLon && lon <= r.MaxLon
+}
+
+// lonContains returns true if the longitude range [lo; hi] contains lon
+func lonContains(lo, hi, lon float64) bool {
+	if lo > hi {
+		return lon >= lo || lon <= hi
+	}
+	return lon >= lo && lon <= hi
+}
+
+// lonContainsRange returns true if the longitude range [alo; ahi] contains
+// the range [blo; bhi].
+func lonContainsRange(alo, ahi, blo, bhi float64) bool {
+	switch {
+	case alo > ahi && blo > bhi:
+		return blo >= alo && bhi <= ahi
+	case alo > ahi:
+		return blo >= alo || bhi <= ahi
+	case blo > bhi:
+		return alo == -180 && ahi == 180
+	default:
+		return blo >= alo && bhi <= ahi
+	}
+}
+
+// lonUnion returns the smallest longitude range containing both given ranges,
+// same algorithm as S2 s1.Interval.Union.
+func lonUnion(alo, ahi, blo, bhi float64) (float64, float64) {
+	if lonContains(alo, ahi, blo) {
+		if lonContains(alo, ahi, bhi) {
+			// Both ends inside, a either contains b or both cover the globe
+			if lonContainsRange(alo, ahi, blo, bhi) {
+				return alo, ahi
+			}
+			return -180, 180
+		}
+		return alo, bhi
+	}
+	if lonContains(alo, ahi, bhi) {
+		return blo, ahi
+	}
+
+	// a is inside b, or both are disjoint
+	if lonContains(blo, bhi, alo) {
+		return blo, bhi
+	}
+	if lonDistance(bhi, alo) < lonDistance(ahi, blo) {
+		return blo, ahi
+	}
+	return alo, bhi
+}
+
+// lonDistance returns the eastward distance in degrees from a to b
+func lonDistance(a, b float64) float64 {
+	if d := b - a; d >= 0 {
+		return d
+	}
+	return b - a + 360
+}
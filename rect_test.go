@@ -0,0 +1,145 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package geopoint_test
+
+import (
+	"math"
+	"testing"
+
+	"go.zenithar.org/geopoint"
+)
+
+func TestRect_Contains(t *testing.T) {
+	tcl := []struct {
+		name     string
+		rect     geopoint.Rect
+		lat, lon float64
+		expected bool
+	}{
+		{name: "Inside", rect: geopoint.Rect{MinLat: 40, MinLon: 0, MaxLat: 45, MaxLon: 5}, lat: 43.6, lon: 1.4, expected: true},
+		{name: "Edge", rect: geopoint.Rect{MinLat: 40, MinLon: 0, MaxLat: 45, MaxLon: 5}, lat: 45, lon: 0, expected: true},
+		{name: "Outside", rect: geopoint.Rect{MinLat: 40, MinLon: 0, MaxLat: 45, MaxLon: 5}, lat: 43.6, lon: -1.4},
+		{name: "Antimeridian east", rect: geopoint.Rect{MinLat: -10, MinLon: 170, MaxLat: 10, MaxLon: -170}, lat: 0, lon: 175, expected: true},
+		{name: "Antimeridian west", rect: geopoint.Rect{MinLat: -10, MinLon: 170, MaxLat: 10, MaxLon: -170}, lat: 0, lon: -180, expected: true},
+		{name: "Antimeridian outside", rect: geopoint.Rect{MinLat: -10, MinLon: 170, MaxLat: 10, MaxLon: -170}, lat: 0, lon: 0},
+		{name: "Empty", rect: geopoint.EmptyRect(), lat: 0, lon: 0},
+		{name: "Full", rect: geopoint.FullRect(), lat: -90, lon: -180, expected: true},
+	}
+
+	for _, tc := range tcl {
+		t.Run(tc.name, func(t *testing.T) {
+			if out := tc.rect.Contains(geopoint.Encode(tc.lat, tc.lon)); out != tc.expected {
+				t.Fatalf("Invalid result: expected %v but got %v", tc.expected, out)
+			}
+		})
+	}
+
+	if geopoint.FullRect().Contains(geopoint.Empty) {
+		t.Fatalf("Invalid result: empty point should not be contained")
+	}
+}
+
+func TestRect_Intersects(t *testing.T) {
+	tcl := []struct {
+		name     string
+		a, b     geopoint.Rect
+		expected bool
+	}{
+		{name: "Overlap", a: geopoint.Rect{MinLat: 0, MinLon: 0, MaxLat: 10, MaxLon: 10}, b: geopoint.Rect{MinLat: 5, MinLon: 5, MaxLat: 15, MaxLon: 15}, expected: true},
+		{name: "Touching", a: geopoint.Rect{MinLat: 0, MinLon: 0, MaxLat: 10, MaxLon: 10}, b: geopoint.Rect{MinLat: 10, MinLon: 10, MaxLat: 15, MaxLon: 15}, expected: true},
+		{name: "Latitude disjoint", a: geopoint.Rect{MinLat: 0, MinLon: 0, MaxLat: 10, MaxLon: 10}, b: geopoint.Rect{MinLat: 11, MinLon: 0, MaxLat: 15, MaxLon: 10}},
+		{name: "Longitude disjoint", a: geopoint.Rect{MinLat: 0, MinLon: 0, MaxLat: 10, MaxLon: 10}, b: geopoint.Rect{MinLat: 0, MinLon: 11, MaxLat: 10, MaxLon: 15}},
+		{name: "Antimeridian", a: geopoint.Rect{MinLat: 0, MinLon: 170, MaxLat: 10, MaxLon: -170}, b: geopoint.Rect{MinLat: 0, MinLon: -175, MaxLat: 10, MaxLon: -160}, expected: true},
+		{name: "Antimeridian reversed", a: geopoint.Rect{MinLat: 0, MinLon: -175, MaxLat: 10, MaxLon: -160}, b: geopoint.Rect{MinLat: 0, MinLon: 170, MaxLat: 10, MaxLon: -170}, expected: true},
+		{name: "Antimeridian disjoint", a: geopoint.Rect{MinLat: 0, MinLon: 170, MaxLat: 10, MaxLon: -170}, b: geopoint.Rect{MinLat: 0, MinLon: -160, MaxLat: 10, MaxLon: 160}},
+		{name: "Both crossing", a: geopoint.Rect{MinLat: 0, MinLon: 170, MaxLat: 10, MaxLon: -170}, b: geopoint.Rect{MinLat: 0, MinLon: 175, MaxLat: 10, MaxLon: -175}, expected: true},
+		{name: "Empty", a: geopoint.EmptyRect(), b: geopoint.FullRect()},
+	}
+
+	for _, tc := range tcl {
+		t.Run(tc.name, func(t *testing.T) {
+			if out := tc.a.Intersects(tc.b); out != tc.expected {
+				t.Fatalf("Invalid result: expected %v but got %v", tc.expected, out)
+			}
+		})
+	}
+}
+
+func TestRect_Union(t *testing.T) {
+	tcl := []struct {
+		name     string
+		a, b     geopoint.Rect
+		expected geopoint.Rect
+	}{
+		{name: "Overlap", a: geopoint.Rect{MinLat: 0, MinLon: 0, MaxLat: 10, MaxLon: 10}, b: geopoint.Rect{MinLat: 5, MinLon: 5, MaxLat: 15, MaxLon: 15}, expected: geopoint.Rect{MinLat: 0, MinLon: 0, MaxLat: 15, MaxLon: 15}},
+		{name: "Contained", a: geopoint.Rect{MinLat: 0, MinLon: 0, MaxLat: 10, MaxLon: 10}, b: geopoint.Rect{MinLat: 2, MinLon: 2, MaxLat: 3, MaxLon: 3}, expected: geopoint.Rect{MinLat: 0, MinLon: 0, MaxLat: 10, MaxLon: 10}},
+		{name: "Disjoint", a: geopoint.Rect{MinLat: 0, MinLon: 0, MaxLat: 10, MaxLon: 10}, b: geopoint.Rect{MinLat: -5, MinLon: 20, MaxLat: 0, MaxLon: 30}, expected: geopoint.Rect{MinLat: -5, MinLon: 0, MaxLat: 10, MaxLon: 30}},
+		{name: "Shortest gap across antimeridian", a: geopoint.Rect{MinLat: 0, MinLon: 170, MaxLat: 10, MaxLon: 175}, b: geopoint.Rect{MinLat: 0, MinLon: -175, MaxLat: 10, MaxLon: -170}, expected: geopoint.Rect{MinLat: 0, MinLon: 170, MaxLat: 10, MaxLon: -170}},
+		{name: "Crossing and regular", a: geopoint.Rect{MinLat: 0, MinLon: 170, MaxLat: 10, MaxLon: -170}, b: geopoint.Rect{MinLat: 0, MinLon: -175, MaxLat: 10, MaxLon: -160}, expected: geopoint.Rect{MinLat: 0, MinLon: 170, MaxLat: 10, MaxLon: -160}},
+		{name: "Covering every longitude", a: geopoint.Rect{MinLat: 0, MinLon: 0, MaxLat: 10, MaxLon: -170}, b: geopoint.Rect{MinLat: 0, MinLon: -175, MaxLat: 10, MaxLon: 5}, expected: geopoint.Rect{MinLat: 0, MinLon: -180, MaxLat: 10, MaxLon: 180}},
+		{name: "Empty", a: geopoint.EmptyRect(), b: geopoint.Rect{MinLat: 0, MinLon: 0, MaxLat: 10, MaxLon: 10}, expected: geopoint.Rect{MinLat: 0, MinLon: 0, MaxLat: 10, MaxLon: 10}},
+	}
+
+	for _, tc := range tcl {
+		t.Run(tc.name, func(t *testing.T) {
+			if out := tc.a.Union(tc.b); out != tc.expected {
+				t.Fatalf("Invalid result: expected %v but got %v", tc.expected, out)
+			}
+			if out := tc.b.Union(tc.a); out != tc.expected {
+				t.Fatalf("Invalid result: expected %v but got %v (reversed)", tc.expected, out)
+			}
+		})
+	}
+}
+
+func TestRect_Expand(t *testing.T) {
+	// One degree of latitude on the mean earth radius
+	degree := 6371008.8 * math.Pi / 180
+
+	tcl := []struct {
+		name     string
+		rect     geopoint.Rect
+		meters   float64
+		expected geopoint.Rect
+	}{
+		{name: "Equator", rect: geopoint.Rect{MinLat: -1, MinLon: -1, MaxLat: 1, MaxLon: 1}, meters: degree, expected: geopoint.Rect{MinLat: -2, MinLon: -2.000152, MaxLat: 2, MaxLon: 2.000152}},
+		{name: "High latitude", rect: geopoint.Rect{MinLat: 59, MinLon: 10, MaxLat: 60, MaxLon: 11}, meters: degree, expected: geopoint.Rect{MinLat: 58, MinLon: 7.999695, MaxLat: 61, MaxLon: 13.000305}},
+		{name: "Antimeridian", rect: geopoint.Rect{MinLat: 0, MinLon: 179.5, MaxLat: 0, MaxLon: 179.5}, meters: degree, expected: geopoint.Rect{MinLat: -1, MinLon: 178.5, MaxLat: 1, MaxLon: -179.5}},
+		{name: "Pole", rect: geopoint.Rect{MinLat: 89.5, MinLon: 10, MaxLat: 89.5, MaxLon: 10}, meters: degree, expected: geopoint.Rect{MinLat: 88.5, MinLon: -180, MaxLat: 90, MaxLon: 180}},
+		{name: "Every longitude", rect: geopoint.Rect{MinLat: 0, MinLon: -179, MaxLat: 0, MaxLon: 179}, meters: degree, expected: geopoint.Rect{MinLat: -1, MinLon: -180, MaxLat: 1, MaxLon: 180}},
+		{name: "Zero", rect: geopoint.Rect{MinLat: 0, MinLon: 0, MaxLat: 1, MaxLon: 1}, meters: 0, expected: geopoint.Rect{MinLat: 0, MinLon: 0, MaxLat: 1, MaxLon: 1}},
+	}
+
+	for _, tc := range tcl {
+		t.Run(tc.name, func(t *testing.T) {
+			out := tc.rect.Expand(tc.meters)
+			if !rectEqual(out, tc.expected) {
+				t.Fatalf("Invalid result: expected %v but got %v", tc.expected, out)
+			}
+		})
+	}
+
+	if !geopoint.EmptyRect().Expand(1000).IsEmpty() {
+		t.Fatalf("Invalid result: expanded empty rect should stay empty")
+	}
+}
+
+func rectEqual(a, b geopoint.Rect) bool {
+	const epsilon = 1e-6
+	return math.Abs(a.MinLat-b.MinLat) < epsilon && math.Abs(a.MinLon-b.MinLon) < epsilon &&
+		math.Abs(a.MaxLat-b.MaxLat) < epsilon && math.Abs(a.MaxLon-b.MaxLon) < epsilon
+}
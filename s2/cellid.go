@@ -0,0 +1,297 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package s2 implements the Google S2 cell decomposition of the sphere, so that
+// points could be bucketed by S2 cells without any external dependency.
+//
+// The sphere is projected on the 6 faces of a cube, each face is recursively
+// split in 4 children, up to 30 levels, and cells are ordered along a Hilbert
+// curve.
+//
+// Most of the code is ported from https://github.com/golang/geo (Apache 2.0,
+// Copyright 2014 Google Inc.).
+package s2
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// CellID uniquely identifies a cell in the S2 cell decomposition.
+//
+// The 3 most significant bits encode the face (0-5), followed by 2 bits per
+// level selecting one of the 4 children along the Hilbert curve, and a single
+// trailing 1 bit. The level of a cell is given by the position of its lowest
+// set bit.
+type CellID uint64
+
+const (
+	// FaceBits is the number of bits used to encode the face number.
+	FaceBits = 3
+	// NumFaces is the number of cube faces.
+	NumFaces = 6
+	// MaxLevel is the level of leaf cells.
+	MaxLevel = 30
+	// PosBits is the number of bits used to encode the Hilbert curve position.
+	PosBits = 2*MaxLevel + 1
+	// MaxSize is the number of leaf cells along each axis of a face.
+	MaxSize = 1 << MaxLevel
+)
+
+// CellIDFromFace returns the level 0 cell of the given cube face.
+func CellIDFromFace(face int) CellID {
+	return CellID((uint64(face) << PosBits) + lsbForLevel(0))
+}
+
+// CellIDFromFacePosLevel returns the cell at the given level containing the
+// given Hilbert curve position of the face.
+func CellIDFromFacePosLevel(face int, pos uint64, level int) CellID {
+	return CellID(uint64(face)<<PosBits + pos | 1).Parent(level)
+}
+
+// CellIDFromLatLng returns the leaf cell containing the given point, expressed
+// in degrees.
+func CellIDFromLatLng(lat, lng float64) CellID {
+	return cellIDFromPoint(pointFromLatLng(lat, lng))
+}
+
+// CellIDFromToken returns the cell of the given token.
+func CellIDFromToken(token string) (CellID, error) {
+	if len(token) == 0 || len(token) > 16 {
+		return CellID(0), ErrInvalidToken
+	}
+	n, err := strconv.ParseUint(token, 16, 64)
+	if err != nil {
+		return CellID(0), ErrInvalidToken
+	}
+
+	// Tokens are right-padded with zeros
+	ci := CellID(n << (4 * uint(16-len(token))))
+	if !ci.IsValid() {
+		return CellID(0), ErrInvalidToken
+	}
+
+	return ci, nil
+}
+
+// Token returns the hex-encoded cell identifier, trailing zeros stripped.
+func (ci CellID) Token() string {
+	s := strings.TrimRight(fmt.Sprintf("%016x", uint64(ci)), "0")
+	if len(s) == 0 {
+		return "X"
+	}
+	return s
+}
+
+// String returns the face followed by the child positions, like "3/0213".
+func (ci CellID) String() string {
+	if !ci.IsValid() {
+		return "Invalid: " + strconv.FormatUint(uint64(ci), 16)
+	}
+	var b strings.Builder
+	b.WriteByte("012345"[ci.Face()])
+	b.WriteByte('/')
+	for level := 1; level <= ci.Level(); level++ {
+		b.WriteByte("0123"[ci.ChildPosition(level)])
+	}
+	return b.String()
+}
+
+// IsValid reports whether ci represents a valid cell.
+func (ci CellID) IsValid() bool {
+	return ci.Face() < NumFaces && (ci.lsb()&0x1555555555555555 != 0)
+}
+
+// Face returns the cube face of the cell, in [0, 5].
+func (ci CellID) Face() int { return int(uint64(ci) >> PosBits) }
+
+// Pos returns the position of the cell along the Hilbert curve of its face.
+func (ci CellID) Pos() uint64 { return uint64(ci) & (^uint64(0) >> FaceBits) }
+
+// Level returns the subdivision level of the cell, in [0, MaxLevel].
+func (ci CellID) Level() int {
+	return MaxLevel - bits.TrailingZeros64(uint64(ci))>>1
+}
+
+// IsLeaf returns whether the cell is at the deepest level.
+func (ci CellID) IsLeaf() bool { return uint64(ci)&1 != 0 }
+
+// ChildPosition returns the position (0..3) of the ancestor at the given level
+// within its parent.
+func (ci CellID) ChildPosition(level int) int {
+	return int(uint64(ci)>>uint64(2*(MaxLevel-level)+1)) & 3
+}
+
+// Parent returns the ancestor at the given level, which must not be greater
+// than the cell level.
+func (ci CellID) Parent(level int) CellID {
+	lsb := lsbForLevel(level)
+	return CellID((uint64(ci) & -lsb) | lsb)
+}
+
+// Children returns the four children of the cell, in Hilbert curve order.
+// Leaf cells have no children.
+func (ci CellID) Children() [4]CellID {
+	var ch [4]CellID
+	lsb := CellID(ci.lsb())
+	ch[0] = ci - lsb + lsb>>2
+	lsb >>= 1
+	ch[1] = ch[0] + lsb
+	ch[2] = ch[1] + lsb
+	ch[3] = ch[2] + lsb
+	return ch
+}
+
+// RangeMin returns the first leaf cell contained by the cell.
+func (ci CellID) RangeMin() CellID { return CellID(uint64(ci) - (ci.lsb() - 1)) }
+
+// RangeMax returns the last leaf cell contained by the cell.
+func (ci CellID) RangeMax() CellID { return CellID(uint64(ci) + (ci.lsb() - 1)) }
+
+// Contains returns true if the given cell is contained by the cell.
+func (ci CellID) Contains(oci CellID) bool {
+	return uint64(ci.RangeMin()) <= uint64(oci) && uint64(oci) <= uint64(ci.RangeMax())
+}
+
+// LatLng returns the center of the cell, expressed in degrees.
+func (ci CellID) LatLng() (lat, lng float64) {
+	face, si, ti := ci.faceSiTi()
+	return latLngFromPoint(faceUVToXYZ(face, stToUV((0.5/MaxSize)*float64(si)), stToUV((0.5/MaxSize)*float64(ti))))
+}
+
+// -----------------------------------------------------------------------------
+
+// lsbForLevel returns the lowest set bit of cells at the given level.
+func lsbForLevel(level int) uint64 { return 1 << uint64(2*(MaxLevel-level)) }
+
+// lsb returns the lowest set bit of the cell.
+func (ci CellID) lsb() uint64 { return uint64(ci) & -uint64(ci) }
+
+// faceSiTi returns the face and the (si, ti) coordinates of the cell center.
+func (ci CellID) faceSiTi() (face int, si, ti uint32) {
+	face, i, j, _ := ci.faceIJOrientation()
+	delta := 0
+	if ci.IsLeaf() {
+		delta = 1
+	} else if (i^(int(ci)>>2))&1 != 0 {
+		delta = 2
+	}
+	return face, uint32(2*i + delta), uint32(2*j + delta)
+}
+
+// faceIJOrientation walks the Hilbert curve to get the (i, j) coordinates of the cell.
+func (ci CellID) faceIJOrientation() (f, i, j, orientation int) {
+	f = ci.Face()
+	orientation = f & swapMask
+	nbits := MaxLevel - 7*lookupBits // first iteration
+
+	// Each iteration maps 8 bits of the Hilbert curve position into 4 bits of
+	// "i" and "j".
+	for k := 7; k >= 0; k-- {
+		orientation += (int(uint64(ci)>>uint64(k*2*lookupBits+1)) & ((1 << uint(2*nbits)) - 1)) << 2
+		orientation = lookupIJ[orientation]
+		i += (orientation >> (lookupBits + 2)) << uint(k*lookupBits)
+		j += ((orientation >> 2) & ((1 << lookupBits) - 1)) << uint(k*lookupBits)
+		orientation &= (swapMask | invertMask)
+		nbits = lookupBits // following iterations
+	}
+
+	// Each "00" pair of the trailing bits of a non-leaf cell reverses the swap bit
+	if ci.lsb()&0x1111111111111110 != 0 {
+		orientation ^= swapMask
+	}
+
+	return
+}
+
+// cellIDFromFaceIJ returns the leaf cell of the given face and (i, j) coordinates.
+func cellIDFromFaceIJ(f, i, j int) CellID {
+	// Shifted one bit to the left at the end
+	n := uint64(f) << (PosBits - 1)
+	// Alternating faces have opposite Hilbert curve orientations
+	b := f & swapMask
+	// Each iteration maps 4 bits of "i" and "j" into 8 bits of the Hilbert
+	// curve position.
+	for k := 7; k >= 0; k-- {
+		mask := (1 << lookupBits) - 1
+		b += ((i >> uint(k*lookupBits)) & mask) << (lookupBits + 2)
+		b += ((j >> uint(k*lookupBits)) & mask) << 2
+		b = lookupPos[b]
+		n |= uint64(b>>2) << (uint(k) * 2 * lookupBits)
+		b &= (swapMask | invertMask)
+	}
+	return CellID(n*2 + 1)
+}
+
+// cellIDFromPoint returns the leaf cell containing the given point.
+func cellIDFromPoint(p vector) CellID {
+	f, u, v := xyzToFaceUV(p)
+	i := stToIJ(uvToST(u))
+	j := stToIJ(uvToST(v))
+	return cellIDFromFaceIJ(f, i, j)
+}
+
+// -----------------------------------------------------------------------------
+
+// Constants related to the bit mangling of the Hilbert curve.
+const (
+	lookupBits = 4
+	swapMask   = 0x01
+	invertMask = 0x02
+)
+
+// lookupPos maps 4 bits of "i", 4 bits of "j" and the 2 orientation bits to the
+// 8 bits of the Hilbert curve position and the new orientation. lookupIJ is the
+// inverted table.
+var (
+	posToIJ = [4][4]int{
+		{0, 1, 3, 2}, // canonical order:    (0,0), (0,1), (1,1), (1,0)
+		{0, 2, 3, 1}, // axes swapped:       (0,0), (1,0), (1,1), (0,1)
+		{3, 2, 0, 1}, // bits inverted:      (1,1), (1,0), (0,0), (0,1)
+		{3, 1, 0, 2}, // swapped & inverted: (1,1), (0,1), (0,0), (1,0)
+	}
+	posToOrientation = [4]int{swapMask, 0, 0, invertMask | swapMask}
+	lookupIJ         [1 << (2*lookupBits + 2)]int
+	lookupPos        [1 << (2*lookupBits + 2)]int
+)
+
+func init() {
+	initLookupCell(0, 0, 0, 0, 0, 0)
+	initLookupCell(0, 0, 0, swapMask, 0, swapMask)
+	initLookupCell(0, 0, 0, invertMask, 0, invertMask)
+	initLookupCell(0, 0, 0, swapMask|invertMask, 0, swapMask|invertMask)
+}
+
+// initLookupCell fills the lookup tables recursively.
+func initLookupCell(level, i, j, origOrientation, pos, orientation int) {
+	if level == lookupBits {
+		ij := (i << lookupBits) + j
+		lookupPos[(ij<<2)+origOrientation] = (pos << 2) + orientation
+		lookupIJ[(pos<<2)+origOrientation] = (ij << 2) + orientation
+		return
+	}
+
+	level++
+	i <<= 1
+	j <<= 1
+	pos <<= 2
+	r := posToIJ[orientation]
+	for k := 0; k < 4; k++ {
+		initLookupCell(level, i+(r[k]>>1), j+(r[k]&1), origOrientation, pos+k, orientation^posToOrientation[k])
+	}
+}
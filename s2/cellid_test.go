@@ -0,0 +1,181 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s2_test
+
+import (
+	"math"
+	"testing"
+
+	"go.zenithar.org/geopoint/s2"
+)
+
+// Vectors from the S2 reference implementation test suite
+var cellLatLngVectors = []struct {
+	id       s2.CellID
+	lat, lng float64
+}{
+	{0x47a1cbd595522b39, 49.703498679, 11.770681595},
+	{0x46525318b63be0f9, 55.685376759, 12.588490937},
+	{0x52b30b71698e729d, 45.486546517, -93.449700022},
+	{0x46ed8886cfadda85, 58.299984854, 23.049300056},
+	{0x3663f18a24cbe857, 34.364439040, 108.330699969},
+	{0x10a06c0a948cf5d, -30.694551352, -30.048758753},
+	{0x2b2bfd076787c5df, -25.285264027, 133.823116966},
+	{0xb09dff882a7809e1, -75.000000031, 0.000000133},
+	{0x94daa3d000000001, -24.694439215, -47.537363213},
+	{0x87a1000000000001, 38.899730392, -99.901813021},
+	{0x4fc76d5000000001, 81.647200334, -55.631712940},
+	{0x3b00955555555555, 10.050986518, 78.293170610},
+	{0x1dcc469991555555, -34.055420593, 18.551140038},
+	{0xb112966aaaaaaaab, -69.219262171, 49.670072392},
+}
+
+func TestCellIDFromLatLng(t *testing.T) {
+	for _, tc := range cellLatLngVectors {
+		if out := s2.CellIDFromLatLng(tc.lat, tc.lng); out != tc.id {
+			t.Fatalf("Invalid result for (%f, %f): expected %x, got %x", tc.lat, tc.lng, uint64(tc.id), uint64(out))
+		}
+
+		lat, lng := tc.id.LatLng()
+		if math.Abs(lat-tc.lat) > 1e-9 || math.Abs(lng-tc.lng) > 1e-9 {
+			t.Fatalf("Invalid result for %x: expected (%f, %f), got (%f, %f)", uint64(tc.id), tc.lat, tc.lng, lat, lng)
+		}
+	}
+}
+
+func TestCellID_Token(t *testing.T) {
+	testCases := []struct {
+		token string
+		id    s2.CellID
+	}{
+		{"1", 0x1000000000000000},
+		{"3", 0x3000000000000000},
+		{"14", 0x1400000000000000},
+		{"41", 0x4100000000000000},
+		{"094", 0x0940000000000000},
+		{"537", 0x5370000000000000},
+		{"3fec", 0x3fec000000000000},
+		{"72f3", 0x72f3000000000000},
+		{"52b8c", 0x52b8c00000000000},
+		{"990ed", 0x990ed00000000000},
+		{"4476dc", 0x4476dc0000000000},
+		{"2a724f", 0x2a724f0000000000},
+		{"7d4afc4", 0x7d4afc4000000000},
+		{"b675785", 0xb675785000000000},
+		{"40cd6124", 0x40cd612400000000},
+		{"3ba32f81", 0x3ba32f8100000000},
+		{"08f569b5c", 0x08f569b5c0000000},
+		{"385327157", 0x3853271570000000},
+		{"166c4d1954", 0x166c4d1954000000},
+		{"96f48d8c39", 0x96f48d8c39000000},
+		{"0bca3c7f74c", 0x0bca3c7f74c00000},
+		{"1ae3619d12f", 0x1ae3619d12f00000},
+		{"07a77802a3fc", 0x07a77802a3fc0000},
+		{"4e7887ec1801", 0x4e7887ec18010000},
+		{"4adad7ae74124", 0x4adad7ae74124000},
+		{"90aba04afe0c5", 0x90aba04afe0c5000},
+		{"8ffc3f02af305c", 0x8ffc3f02af305c00},
+		{"6fa47550938183", 0x6fa4755093818300},
+		{"aa80a565df5e7fc", 0xaa80a565df5e7fc0},
+		{"01614b5e968e121", 0x01614b5e968e1210},
+		{"aa05238e7bd3ee7c", 0xaa05238e7bd3ee7c},
+		{"48a23db9c2963e5b", 0x48a23db9c2963e5b},
+	}
+
+	for _, tc := range testCases {
+		id, err := s2.CellIDFromToken(tc.token)
+		if err != nil {
+			t.Fatalf("Unable to parse %q, got error %v", tc.token, err)
+		}
+		if id != tc.id {
+			t.Fatalf("Invalid result for %q: expected %x, got %x", tc.token, uint64(tc.id), uint64(id))
+		}
+		if out := id.Token(); out != tc.token {
+			t.Fatalf("Invalid result: expected %q, got %q", tc.token, out)
+		}
+	}
+}
+
+func TestCellIDFromToken_Invalid(t *testing.T) {
+	for _, token := range []string{"", "X", "876b e99", "876bee99\n", "876[ee99", " 876bee99", "ffffffffffffffff", "e", "48a23db9c2963e5b0"} {
+		if _, err := s2.CellIDFromToken(token); err != s2.ErrInvalidToken {
+			t.Fatalf("Invalid result for %q: expected ErrInvalidToken, got %v", token, err)
+		}
+	}
+
+	if out := s2.CellID(0).Token(); out != "X" {
+		t.Fatalf("Invalid result: expected %q, got %q", "X", out)
+	}
+}
+
+func TestCellID_Hierarchy(t *testing.T) {
+	ci := s2.CellIDFromFacePosLevel(3, 0x12345678, s2.MaxLevel-4)
+	if !ci.IsValid() || ci.IsLeaf() {
+		t.Fatalf("Invalid result: %x should be a valid non-leaf cell", uint64(ci))
+	}
+	if ci.Face() != 3 || ci.Pos() != 0x12345700 || ci.Level() != 26 {
+		t.Fatalf("Invalid result: got face %d, pos %x, level %d", ci.Face(), ci.Pos(), ci.Level())
+	}
+	if kid0 := ci.Children()[0].Pos(); kid0 != 0x12345640 {
+		t.Fatalf("Invalid result: expected first child 0x12345640, got %x", kid0)
+	}
+	if parent := ci.Parent(ci.Level() - 2).Pos(); parent != 0x12345000 {
+		t.Fatalf("Invalid result: expected parent 0x12345000, got %x", parent)
+	}
+
+	for face := 0; face < s2.NumFaces; face++ {
+		if s2.CellIDFromFacePosLevel(face, 0, 0) != s2.CellIDFromFace(face) {
+			t.Fatalf("Invalid result: face %d cell mismatch", face)
+		}
+	}
+
+	// Pittsburgh
+	a, b, c, d := s2.CellID(0x80855c0000000000), s2.CellID(0x80855d0000000000), s2.CellID(0x80855dc000000000), s2.CellID(0x8085630000000000)
+	if !a.Contains(b) || !a.Contains(c) || !b.Contains(c) || b.Contains(a) || a.Contains(d) || d.Contains(c) {
+		t.Fatalf("Invalid result: unexpected containment")
+	}
+
+	if out := s2.CellID(0xbb04000000000000).String(); out != "5/31200" {
+		t.Fatalf("Invalid result: expected %q, got %q", "5/31200", out)
+	}
+}
+
+func TestCellID_HilbertContinuity(t *testing.T) {
+	// Consecutive cells along the curve must be adjacent, even across faces:
+	// the distance between their centers is bounded by the cell size.
+	const level = 5
+	begin := s2.CellIDFromFace(0).RangeMin().Parent(level)
+	end := s2.CellIDFromFace(s2.NumFaces - 1).RangeMax().Parent(level)
+	step := 2 * (begin - begin.RangeMin() + 1)
+
+	prevLat, prevLng := begin.LatLng()
+	for ci := begin + step; ci <= end; ci += step {
+		lat, lng := ci.LatLng()
+		if d := angle(prevLat, prevLng, lat, lng); d > 90/math.Exp2(level)*1.5 {
+			t.Fatalf("Invalid result: cells %s and %s are %f° apart", ci-step, ci, d)
+		}
+		prevLat, prevLng = lat, lng
+	}
+}
+
+// angle returns the angle between two points, in degrees.
+func angle(lat1, lng1, lat2, lng2 float64) float64 {
+	p1, p2 := lat1*math.Pi/180, lat2*math.Pi/180
+	dl := (lng2 - lng1) * math.Pi / 180
+	c := math.Sin(p1)*math.Sin(p2) + math.Cos(p1)*math.Cos(p2)*math.Cos(dl)
+	return math.Acos(math.Min(1, c)) * 180 / math.Pi
+}
@@ -0,0 +1,26 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s2
+
+import "errors"
+
+var (
+	// ErrInvalidToken is raised when the given token is not a valid cell identifier
+	ErrInvalidToken = errors.New("s2: invalid cell token")
+	// ErrInvalidLevel is raised when the given level is not in [0, MaxLevel]
+	ErrInvalidLevel = errors.New("s2: invalid cell level")
+)
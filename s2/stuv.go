@@ -0,0 +1,136 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s2
+
+import "math"
+
+// vector is a point of the 3D space, points of the unit sphere are used to
+// project (lat, lng) coordinates on the cube faces.
+type vector struct {
+	X, Y, Z float64
+}
+
+// pointFromLatLng returns the unit sphere point of the given coordinates, in
+// degrees.
+func pointFromLatLng(lat, lng float64) vector {
+	phi := lat * (math.Pi / 180)
+	theta := lng * (math.Pi / 180)
+	cosphi := math.Cos(phi)
+	return vector{math.Cos(theta) * cosphi, math.Sin(theta) * cosphi, math.Sin(phi)}
+}
+
+// latLngFromPoint returns the coordinates, in degrees, of the given point. The
+// point does not need to be normalized.
+func latLngFromPoint(p vector) (lat, lng float64) {
+	lat = math.Atan2(p.Z, math.Sqrt(p.X*p.X+p.Y*p.Y)) * (180 / math.Pi)
+	lng = math.Atan2(p.Y, p.X) * (180 / math.Pi)
+	return lat, lng
+}
+
+// -----------------------------------------------------------------------------
+// Cube-face projection
+//
+// A point is projected on the face of its largest absolute component, giving
+// (u, v) coordinates in [-1, 1]. They are transformed to (s, t) in [0, 1] with
+// a quadratic projection so that cells have roughly the same area, and (i, j)
+// are the leaf cell coordinates in [0, MaxSize-1].
+
+// face returns the cube face of the given point.
+func face(p vector) int {
+	x, y, z := math.Abs(p.X), math.Abs(p.Y), math.Abs(p.Z)
+	f := 2
+	if x > y {
+		if x > z {
+			f = 0
+		}
+	} else if y > z {
+		f = 1
+	}
+
+	switch {
+	case f == 0 && p.X < 0, f == 1 && p.Y < 0, f == 2 && p.Z < 0:
+		f += 3
+	}
+
+	return f
+}
+
+// xyzToFaceUV returns the face and the (u, v) coordinates of the given point.
+func xyzToFaceUV(p vector) (f int, u, v float64) {
+	f = face(p)
+	switch f {
+	case 0:
+		u, v = p.Y/p.X, p.Z/p.X
+	case 1:
+		u, v = -p.X/p.Y, p.Z/p.Y
+	case 2:
+		u, v = -p.X/p.Z, -p.Y/p.Z
+	case 3:
+		u, v = p.Z/p.X, p.Y/p.X
+	case 4:
+		u, v = p.Z/p.Y, -p.X/p.Y
+	default:
+		u, v = -p.Y/p.Z, -p.X/p.Z
+	}
+	return f, u, v
+}
+
+// faceUVToXYZ returns the (unnormalized) point of the given face coordinates.
+func faceUVToXYZ(face int, u, v float64) vector {
+	switch face {
+	case 0:
+		return vector{1, u, v}
+	case 1:
+		return vector{-u, 1, v}
+	case 2:
+		return vector{-u, -v, 1}
+	case 3:
+		return vector{-1, -v, -u}
+	case 4:
+		return vector{v, -1, -u}
+	default:
+		return vector{v, u, -1}
+	}
+}
+
+// stToUV converts the s or t coordinate to u or v.
+func stToUV(s float64) float64 {
+	if s >= 0.5 {
+		return (1 / 3.) * (4*s*s - 1)
+	}
+	return (1 / 3.) * (1 - 4*(1-s)*(1-s))
+}
+
+// uvToST converts the u or v coordinate to s or t.
+func uvToST(u float64) float64 {
+	if u >= 0 {
+		return 0.5 * math.Sqrt(1+3*u)
+	}
+	return 1 - 0.5*math.Sqrt(1-3*u)
+}
+
+// stToIJ converts the s or t coordinate to the leaf cell i or j coordinate.
+func stToIJ(s float64) int {
+	i := int(math.Floor(MaxSize * s))
+	switch {
+	case i < 0:
+		return 0
+	case i > MaxSize-1:
+		return MaxSize - 1
+	}
+	return i
+}
@@ -0,0 +1,46 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s2
+
+import "go.zenithar.org/geopoint"
+
+// FromValue returns the cell at the given level containing the given point.
+// Points with a precision level are located with the south-west corner of
+// their cell.
+func FromValue(v geopoint.Value, level int) (CellID, error) {
+	if level < 0 || level > MaxLevel {
+		return CellID(0), ErrInvalidLevel
+	}
+
+	lat, lng, err := geopoint.Decode(v)
+	if err != nil {
+		return CellID(0), err
+	}
+
+	return CellIDFromLatLng(lat, lng).Parent(level), nil
+}
+
+// Value returns the center of the cell, rounded to the micro-degree. Invalid
+// cells return geopoint.Empty.
+func (ci CellID) Value() geopoint.Value {
+	if !ci.IsValid() {
+		return geopoint.Empty
+	}
+
+	lat, lng := ci.LatLng()
+	return geopoint.Encode(lat, lng)
+}
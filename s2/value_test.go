@@ -0,0 +1,79 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s2_test
+
+import (
+	"testing"
+
+	"go.zenithar.org/geopoint"
+	"go.zenithar.org/geopoint/s2"
+)
+
+func TestFromValue(t *testing.T) {
+	// The last vectors are centers and corners of coarser cells, the
+	// micro-degree rounding may move them to a neighbor
+	for _, tc := range cellLatLngVectors[:8] {
+		// Values are rounded to the micro-degree, compare ~20m cells
+		out, err := s2.FromValue(geopoint.Encode(tc.lat, tc.lng), 19)
+		if err != nil {
+			t.Fatalf("Unable to convert (%f, %f), got error %v", tc.lat, tc.lng, err)
+		}
+		if expected := tc.id.Parent(19); out != expected {
+			t.Fatalf("Invalid result for (%f, %f): expected %s, got %s", tc.lat, tc.lng, expected, out)
+		}
+	}
+
+	if _, err := s2.FromValue(geopoint.Empty, 10); err != geopoint.ErrEmptyGeoPoint {
+		t.Fatalf("Invalid result: expected ErrEmptyGeoPoint, got %v", err)
+	}
+	for _, level := range []int{-1, s2.MaxLevel + 1} {
+		if _, err := s2.FromValue(geopoint.Encode(0, 0), level); err != s2.ErrInvalidLevel {
+			t.Fatalf("Invalid result for level %d: expected ErrInvalidLevel, got %v", level, err)
+		}
+	}
+}
+
+func TestCellID_Value(t *testing.T) {
+	points := []geopoint.Value{
+		geopoint.Encode(43.603574, 1.442917),
+		geopoint.Encode(-34.603722, -58.381592),
+		geopoint.Encode(89.999999, 179.999999),
+		geopoint.Encode(-90, -180),
+		geopoint.Encode(0, 0),
+	}
+
+	// Cell centers are still inside the cell once rounded to the micro-degree
+	for _, p := range points {
+		for level := 0; level <= 22; level++ {
+			ci, err := s2.FromValue(p, level)
+			if err != nil {
+				t.Fatalf("Unable to convert %d, got error %v", p, err)
+			}
+			out, err := s2.FromValue(ci.Value(), level)
+			if err != nil {
+				t.Fatalf("Unable to convert %d, got error %v", ci.Value(), err)
+			}
+			if out != ci {
+				t.Fatalf("Invalid round trip at level %d, expected %s, got %s", level, ci, out)
+			}
+		}
+	}
+
+	if out := s2.CellID(0).Value(); out != geopoint.Empty {
+		t.Fatalf("Invalid result: expected Empty, got %d", out)
+	}
+}
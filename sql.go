@@ -0,0 +1,104 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package geopoint
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+)
+
+// Value implements driver.Valuer, the point is stored as the int64 bit
+// pattern of its encoded value. The empty point is stored as NULL.
+func (p Value) Value() (driver.Value, error) {
+	if p == Empty {
+		return nil, nil
+	}
+	return int64(p), nil
+}
+
+// Scan implements sql.Scanner. The point could be stored as an integer, as
+// its 8-byte binary form or as its encoded string. NULL is scanned as the
+// empty point.
+func (p *Value) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*p = Empty
+		return nil
+	case int64:
+		value := Value(uint64(v))
+		if err := validate(value); err != nil {
+			return err
+		}
+		*p = value
+		return nil
+	case []byte:
+		// Short codes are 8 characters long too
+		if len(v) == 8 && !isCodeText(v) {
+			return p.UnmarshalBinary(v)
+		}
+		return p.UnmarshalText(v)
+	case string:
+		return p.UnmarshalText([]byte(v))
+	default:
+		return fmt.Errorf("geopoint: unable to scan %T into Value", src)
+	}
+}
+
+// isCodeText returns true if the given bytes look like an encoded string. The
+// first byte of a valid binary point is never a printable character.
+func isCodeText(data []byte) bool {
+	if bytes.IndexByte(data, ':') < 0 {
+		return false
+	}
+	for _, c := range data {
+		if c < '!' || c > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// -----------------------------------------------------------------------------
+
+// NullValue represents a point that may be null
+type NullValue struct {
+	Point Value
+	Valid bool // Valid is true if Point is not NULL
+}
+
+// Scan implements sql.Scanner
+func (n *NullValue) Scan(src interface{}) error {
+	if src == nil {
+		n.Point, n.Valid = Empty, false
+		return nil
+	}
+	if err := n.Point.Scan(src); err != nil {
+		n.Valid = false
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value implements driver.Valuer
+func (n NullValue) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Point.Value()
+}
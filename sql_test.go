@@ -0,0 +1,234 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package geopoint_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"sync"
+	"testing"
+
+	"go.zenithar.org/geopoint"
+)
+
+// fakeDriver is a minimal in-memory driver storing a single column table.
+// "INSERT" statements append their argument, any other statement returns all
+// stored rows.
+type fakeDriver struct {
+	sync.Mutex
+	rows []driver.Value
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	return &fakeConn{driver: d}, nil
+}
+
+type fakeConn struct {
+	driver *fakeDriver
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{conn: c, query: query}, nil
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) { return c, nil }
+
+func (c *fakeConn) Commit() error { return nil }
+
+func (c *fakeConn) Rollback() error { return nil }
+
+type fakeStmt struct {
+	conn  *fakeConn
+	query string
+}
+
+func (s *fakeStmt) Close() error { return nil }
+
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.conn.driver.Lock()
+	defer s.conn.driver.Unlock()
+	s.conn.driver.rows = append(s.conn.driver.rows, args[0])
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.conn.driver.Lock()
+	defer s.conn.driver.Unlock()
+	rows := make([]driver.Value, len(s.conn.driver.rows))
+	copy(rows, s.conn.driver.rows)
+	return &fakeRows{rows: rows}, nil
+}
+
+type fakeRows struct {
+	rows []driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return []string{"point"} }
+
+func (r *fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	dest[0], r.rows = r.rows[0], r.rows[1:]
+	return nil
+}
+
+var fakeDB = &fakeDriver{}
+
+func init() {
+	sql.Register("geopoint-fake", fakeDB)
+}
+
+func openFakeDB(t *testing.T) *sql.DB {
+	fakeDB.Lock()
+	fakeDB.rows = nil
+	fakeDB.Unlock()
+
+	db, err := sql.Open("geopoint-fake", "")
+	if err != nil {
+		t.Fatalf("unable to open database, got error %v", err)
+	}
+	return db
+}
+
+// -----------------------------------------------------------------------------
+
+func TestValue_SQL(t *testing.T) {
+	db := openFakeDB(t)
+	defer db.Close()
+
+	p := geopoint.Value(75071809151126838)
+	inputs := []interface{}{
+		p,
+		int64(75071809151126838),
+		"10AB5:69A51:94D36",
+		[]byte("10AB5:69A51:94D36"),
+		[]byte{0x01, 0x0A, 0xB5, 0x69, 0xA5, 0x19, 0x4D, 0x36},
+	}
+	for _, in := range inputs {
+		if _, err := db.Exec("INSERT", in); err != nil {
+			t.Fatalf("unable to insert %v, got error %v", in, err)
+		}
+	}
+
+	// Valuer must store the int64 bit pattern
+	if v, ok := fakeDB.rows[0].(int64); !ok || v != 75071809151126838 {
+		t.Fatalf("invalid stored value, expected int64, got %T(%v)", fakeDB.rows[0], fakeDB.rows[0])
+	}
+
+	rows, err := db.Query("SELECT")
+	if err != nil {
+		t.Fatalf("unable to query, got error %v", err)
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		var out geopoint.Value
+		if err := rows.Scan(&out); err != nil {
+			t.Fatalf("unable to scan row %d, got error %v", count, err)
+		}
+		if out != p {
+			t.Fatalf("invalid point for row %d, expected %d, got %d", count, p, out)
+		}
+		count++
+	}
+	if count != len(inputs) {
+		t.Fatalf("invalid row count, expected %d, got %d", len(inputs), count)
+	}
+}
+
+func TestValue_Scan(t *testing.T) {
+	tcl := []struct {
+		name        string
+		src         interface{}
+		expectedErr error
+	}{
+		{name: "Unknown version", src: int64(-1), expectedErr: geopoint.ErrUnsupportedVersion},
+		{name: "Invalid code", src: "10AB5:69A51:94D36:0", expectedErr: geopoint.ErrInvalidGeoPointHash},
+		{name: "Invalid binary", src: []byte{0x0F, 0xFF, 0, 0, 0, 0, 0, 0}, expectedErr: geopoint.ErrInvalidGeoPointValue},
+	}
+
+	for _, tc := range tcl {
+		t.Run(tc.name, func(t *testing.T) {
+			var p geopoint.Value
+			if err := p.Scan(tc.src); err != tc.expectedErr {
+				t.Fatalf("Invalid result: Error expected %v, got %v.", tc.expectedErr, err)
+			}
+		})
+	}
+
+	// Level 2 code has the length of the binary form
+	var p geopoint.Value
+	code := geopoint.EncodeWithPrecision(43.603574, 1.442917, 2)
+	if err := p.Scan([]byte(code.Code())); err != nil || p != code {
+		t.Fatalf("Invalid result: expected %s, got %s (%v)", code.Code(), p.Code(), err)
+	}
+
+	if err := p.Scan(nil); err != nil || p != geopoint.Empty {
+		t.Fatalf("NULL should be scanned as the empty point, got %d (%v)", p, err)
+	}
+	if err := p.Scan(3.14); err == nil {
+		t.Fatal("error should be raised when scanning a float")
+	}
+}
+
+func TestNullValue_SQL(t *testing.T) {
+	db := openFakeDB(t)
+	defer db.Close()
+
+	inputs := []geopoint.NullValue{
+		{Point: geopoint.Value(75071809151126838), Valid: true},
+		{Point: geopoint.Empty},
+	}
+	for _, in := range inputs {
+		if _, err := db.Exec("INSERT", in); err != nil {
+			t.Fatalf("unable to insert %v, got error %v", in, err)
+		}
+	}
+	if fakeDB.rows[1] != nil {
+		t.Fatalf("invalid stored value, expected NULL, got %v", fakeDB.rows[1])
+	}
+
+	rows, err := db.Query("SELECT")
+	if err != nil {
+		t.Fatalf("unable to query, got error %v", err)
+	}
+	defer rows.Close()
+
+	i := 0
+	for rows.Next() {
+		var out geopoint.NullValue
+		if err := rows.Scan(&out); err != nil {
+			t.Fatalf("unable to scan row %d, got error %v", i, err)
+		}
+		if out != inputs[i] {
+			t.Fatalf("invalid value for row %d, expected %v, got %v", i, inputs[i], out)
+		}
+		i++
+	}
+	if i != len(inputs) {
+		t.Fatalf("invalid row count, expected %d, got %d", len(inputs), i)
+	}
+}
@@ -0,0 +1,235 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package geopoint
+
+import (
+	"math"
+	"strings"
+)
+
+// Web Mercator (EPSG:3857) slippy map tiles.
+//
+// At zoom level z, the world is split in 2^z x 2^z square tiles, x growing
+// eastward from 180°W and y growing southward from the northernmost latitude
+// of the projection (~85.0511°). Points beyond that latitude belong to the
+// first or last row of tiles.
+const (
+	// MaxZoom is the deepest supported zoom level
+	MaxZoom = 30
+	// MaxMercatorLatitude is the latitude of the projection edges, in degrees
+	// (atan(sinh(π)) in radians)
+	MaxMercatorLatitude = 85.05112877980659
+)
+
+// TileOf returns the coordinates of the tile containing the given point at the
+// given zoom level. Points with a precision level are located with the
+// south-west corner of their cell.
+func TileOf(v Value, zoom int) (x, y int, err error) {
+	if zoom < 0 || zoom > MaxZoom {
+		return 0, 0, ErrInvalidZoom
+	}
+	lat, lon, err := Decode(v)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	fx, fy := tileCoordinates(lat, lon, zoom)
+	return clampTile(math.Floor(fx), zoom), clampTile(math.Floor(fy), zoom), nil
+}
+
+// TileBounds returns the area covered by the given tile.
+func TileBounds(zoom, x, y int) (Rect, error) {
+	if err := checkTile(zoom, x, y); err != nil {
+		return Rect{}, err
+	}
+
+	n := float64(uint(1) << uint(zoom))
+	tileLat := func(y int) float64 {
+		return math.Atan(math.Sinh(math.Pi*(1-2*float64(y)/n))) * 180 / math.Pi
+	}
+
+	return Rect{
+		MinLat: tileLat(y + 1),
+		MinLon: float64(x)/n*360 - 180,
+		MaxLat: tileLat(y),
+		MaxLon: float64(x+1)/n*360 - 180,
+	}, nil
+}
+
+// QuadKey returns the Bing Maps quadkey of the tile containing the given point
+// at the given zoom level. The quadkey has one digit per zoom level.
+func QuadKey(v Value, zoom int) (string, error) {
+	x, y, err := TileOf(v, zoom)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	b.Grow(zoom)
+	for i := zoom; i > 0; i-- {
+		mask := 1 << uint(i-1)
+		digit := byte('0')
+		if x&mask != 0 {
+			digit++
+		}
+		if y&mask != 0 {
+			digit += 2
+		}
+		b.WriteByte(digit)
+	}
+
+	return b.String(), nil
+}
+
+// FromQuadKey returns the tile of the given Bing Maps quadkey.
+func FromQuadKey(key string) (zoom, x, y int, err error) {
+	if len(key) > MaxZoom {
+		return 0, 0, 0, ErrInvalidQuadKey
+	}
+
+	for i := 0; i < len(key); i++ {
+		x <<= 1
+		y <<= 1
+		switch key[i] {
+		case '0':
+		case '1':
+			x |= 1
+		case '2':
+			y |= 1
+		case '3':
+			x |= 1
+			y |= 1
+		default:
+			return 0, 0, 0, ErrInvalidQuadKey
+		}
+	}
+
+	return len(key), x, y, nil
+}
+
+// TileIterator iterates over the tiles covering an area, row by row.
+//
+//	it, err := geopoint.TilesCovering(bounds, 12)
+//	for it.Next() {
+//		x, y := it.Tile()
+//	}
+type TileIterator struct {
+	n            int
+	minX, minY   int
+	width, total int
+	index        int
+}
+
+// TilesCovering returns an iterator over the tiles covering the given area at
+// the given zoom level. An area with MinLon greater than MaxLon crosses the
+// antimeridian. Tiles only touching the area on its south or east edge are
+// excluded.
+func TilesCovering(bounds Rect, zoom int) (*TileIterator, error) {
+	if zoom < 0 || zoom > MaxZoom {
+		return nil, ErrInvalidZoom
+	}
+	if math.IsNaN(bounds.MinLat) || math.IsNaN(bounds.MaxLat) || math.IsNaN(bounds.MinLon) || math.IsNaN(bounds.MaxLon) || bounds.MinLat > bounds.MaxLat {
+		return nil, ErrInvalidGeoPointValue
+	}
+
+	n := 1 << uint(zoom)
+	west, north := tileCoordinates(bounds.MaxLat, bounds.MinLon, zoom)
+	east, south := tileCoordinates(bounds.MinLat, bounds.MaxLon, zoom)
+	minX, minY := clampTile(math.Floor(west), zoom), clampTile(math.Floor(north), zoom)
+	maxX, maxY := clampTile(math.Ceil(east)-1, zoom), clampTile(math.Ceil(south)-1, zoom)
+
+	// Degenerated areas still belong to a tile
+	if maxY < minY {
+		maxY = minY
+	}
+	width := maxX - minX + 1
+	if bounds.MinLon > bounds.MaxLon {
+		width += n
+	}
+	switch {
+	case width < 1:
+		width = 1
+	case width > n:
+		width = n
+	}
+
+	return &TileIterator{
+		n:     n,
+		minX:  minX,
+		minY:  minY,
+		width: width,
+		total: width * (maxY - minY + 1),
+		index: -1,
+	}, nil
+}
+
+// Len returns the number of tiles covering the area.
+func (it *TileIterator) Len() int {
+	return it.total
+}
+
+// Next moves to the next tile, it returns false when all tiles have been
+// visited.
+func (it *TileIterator) Next() bool {
+	if it.index < it.total {
+		it.index++
+	}
+	return it.index < it.total
+}
+
+// Tile returns the coordinates of the current tile.
+func (it *TileIterator) Tile() (x, y int) {
+	return (it.minX + it.index%it.width) % it.n, it.minY + it.index/it.width
+}
+
+// -----------------------------------------------------------------------------
+
+// tileCoordinates returns the fractional tile coordinates of the given point.
+func tileCoordinates(lat, lon float64, zoom int) (x, y float64) {
+	n := float64(uint(1) << uint(zoom))
+	lat = math.Max(-MaxMercatorLatitude, math.Min(MaxMercatorLatitude, lat))
+
+	phi := lat * math.Pi / 180
+	x = (lon + 180) / 360 * n
+	y = (1 - math.Log(math.Tan(phi)+1/math.Cos(phi))/math.Pi) / 2 * n
+
+	return x, y
+}
+
+// clampTile returns the tile coordinate clamped to the tiles of the zoom level.
+func clampTile(v float64, zoom int) int {
+	n := 1 << uint(zoom)
+	switch {
+	case v < 0:
+		return 0
+	case v >= float64(n):
+		return n - 1
+	}
+	return int(v)
+}
+
+// checkTile returns nil if the given tile exists.
+func checkTile(zoom, x, y int) error {
+	if zoom < 0 || zoom > MaxZoom {
+		return ErrInvalidZoom
+	}
+	n := 1 << uint(zoom)
+	if x < 0 || x >= n || y < 0 || y >= n {
+		return ErrInvalidTile
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package geopoint_test
+
+import (
+	"math"
+	"testing"
+
+	"go.zenithar.org/geopoint"
+)
+
+func TestTileOf(t *testing.T) {
+	testCases := []struct {
+		lat, lon float64
+		zoom     int
+		x, y     int
+	}{
+		{0, 0, 0, 0, 0},
+		{51.5074, -0.1278, 10, 511, 340},
+		{48.8582, 2.2945, 15, 16592, 11272},
+		{-33.8688, 151.2093, 12, 3768, 2457},
+		{43.603574, 1.442917, 18, 132122, 95720},
+		// Clamped latitudes
+		{85.0511, 0, 2, 2, 0},
+		{89.5, -180, 2, 0, 0},
+		{90, 179.999999, 2, 3, 0},
+		{-90, 0, 2, 2, 3},
+		{-85.06, 0, 30, 1 << 29, 1<<30 - 1},
+	}
+
+	for _, tc := range testCases {
+		x, y, err := geopoint.TileOf(geopoint.Encode(tc.lat, tc.lon), tc.zoom)
+		if err != nil {
+			t.Fatalf("Unable to locate (%f, %f), got error %v", tc.lat, tc.lon, err)
+		}
+		if x != tc.x || y != tc.y {
+			t.Fatalf("Invalid result for (%f, %f): expected %d/%d/%d, got %d/%d/%d", tc.lat, tc.lon, tc.zoom, tc.x, tc.y, tc.zoom, x, y)
+		}
+	}
+
+	if _, _, err := geopoint.TileOf(geopoint.Encode(0, 0), geopoint.MaxZoom+1); err != geopoint.ErrInvalidZoom {
+		t.Fatalf("Invalid result: expected ErrInvalidZoom, got %v", err)
+	}
+	if _, _, err := geopoint.TileOf(geopoint.Empty, 10); err != geopoint.ErrEmptyGeoPoint {
+		t.Fatalf("Invalid result: expected ErrEmptyGeoPoint, got %v", err)
+	}
+}
+
+func TestTileBounds(t *testing.T) {
+	testCases := []struct {
+		zoom, x, y int
+		expected   geopoint.Rect
+	}{
+		{0, 0, 0, geopoint.Rect{MinLat: -geopoint.MaxMercatorLatitude, MinLon: -180, MaxLat: geopoint.MaxMercatorLatitude, MaxLon: 180}},
+		{1, 1, 0, geopoint.Rect{MinLat: 0, MinLon: 0, MaxLat: geopoint.MaxMercatorLatitude, MaxLon: 180}},
+		{10, 511, 340, geopoint.Rect{MinLat: 51.399206, MinLon: -0.351563, MaxLat: 51.618017, MaxLon: 0}},
+	}
+
+	for _, tc := range testCases {
+		out, err := geopoint.TileBounds(tc.zoom, tc.x, tc.y)
+		if err != nil {
+			t.Fatalf("Unable to compute bounds of %d/%d/%d, got error %v", tc.zoom, tc.x, tc.y, err)
+		}
+		if math.Abs(out.MinLat-tc.expected.MinLat) > 1e-6 || math.Abs(out.MinLon-tc.expected.MinLon) > 1e-6 ||
+			math.Abs(out.MaxLat-tc.expected.MaxLat) > 1e-6 || math.Abs(out.MaxLon-tc.expected.MaxLon) > 1e-6 {
+			t.Fatalf("Invalid result for %d/%d/%d: expected %+v, got %+v", tc.zoom, tc.x, tc.y, tc.expected, out)
+		}
+	}
+
+	for _, tile := range [][3]int{{-1, 0, 0}, {31, 0, 0}, {2, 4, 0}, {2, 0, -1}} {
+		if _, err := geopoint.TileBounds(tile[0], tile[1], tile[2]); err == nil {
+			t.Fatalf("Invalid result for %v: expected error", tile)
+		}
+	}
+}
+
+func TestMaxMercatorLatitude(t *testing.T) {
+	expected := math.Atan(math.Sinh(math.Pi)) * 180 / math.Pi
+	if geopoint.MaxMercatorLatitude != expected {
+		t.Fatalf("Invalid result: expected %v, got %v", expected, geopoint.MaxMercatorLatitude)
+	}
+}
+
+func TestQuadKey(t *testing.T) {
+	// Bing Maps tile system documentation example
+	zoom, x, y, err := geopoint.FromQuadKey("213")
+	if err != nil {
+		t.Fatalf("Unable to decode quadkey, got error %v", err)
+	}
+	if zoom != 3 || x != 3 || y != 5 {
+		t.Fatalf("Invalid result: expected 3/3/5, got %d/%d/%d", zoom, x, y)
+	}
+
+	for _, p := range randomPoints(1000) {
+		for _, zoom := range []int{0, 1, 12, geopoint.MaxZoom} {
+			key, err := geopoint.QuadKey(p, zoom)
+			if err != nil {
+				t.Fatalf("Unable to encode %d, got error %v", p, err)
+			}
+			if len(key) != zoom {
+				t.Fatalf("Invalid result: expected %d digits, got %q", zoom, key)
+			}
+
+			x, y, _ := geopoint.TileOf(p, zoom)
+			outZoom, outX, outY, err := geopoint.FromQuadKey(key)
+			if err != nil {
+				t.Fatalf("Unable to decode %q, got error %v", key, err)
+			}
+			if outZoom != zoom || outX != x || outY != y {
+				t.Fatalf("Invalid round trip for %q, expected %d/%d/%d, got %d/%d/%d", key, zoom, x, y, outZoom, outX, outY)
+			}
+		}
+	}
+
+	for _, key := range []string{"214", "21a", "0123012301230123012301230123012"} {
+		if _, _, _, err := geopoint.FromQuadKey(key); err != geopoint.ErrInvalidQuadKey {
+			t.Fatalf("Invalid result for %q: expected ErrInvalidQuadKey, got %v", key, err)
+		}
+	}
+}
+
+func TestTilesCovering(t *testing.T) {
+	tile, _ := geopoint.TileBounds(10, 511, 340)
+
+	testCases := []struct {
+		bounds   geopoint.Rect
+		zoom     int
+		expected [][2]int
+	}{
+		// Tile bounds only cover the tile itself
+		{tile, 10, [][2]int{{511, 340}}},
+		{tile, 11, [][2]int{{1022, 680}, {1023, 680}, {1022, 681}, {1023, 681}}},
+		// Antimeridian
+		{geopoint.Rect{MinLat: -10, MinLon: 100, MaxLat: 10, MaxLon: -100}, 2, [][2]int{{3, 1}, {0, 1}, {3, 2}, {0, 2}}},
+		// Clamped latitudes
+		{geopoint.Rect{MinLat: -90, MinLon: -180, MaxLat: 90, MaxLon: 180}, 1, [][2]int{{0, 0}, {1, 0}, {0, 1}, {1, 1}}},
+		// Point
+		{geopoint.Rect{MinLat: 51.5074, MinLon: -0.1278, MaxLat: 51.5074, MaxLon: -0.1278}, 10, [][2]int{{511, 340}}},
+	}
+
+	for _, tc := range testCases {
+		it, err := geopoint.TilesCovering(tc.bounds, tc.zoom)
+		if err != nil {
+			t.Fatalf("Unable to cover %+v, got error %v", tc.bounds, err)
+		}
+		if it.Len() != len(tc.expected) {
+			t.Fatalf("Invalid result for %+v: expected %d tiles, got %d", tc.bounds, len(tc.expected), it.Len())
+		}
+
+		i := 0
+		for it.Next() {
+			x, y := it.Tile()
+			if i >= len(tc.expected) || x != tc.expected[i][0] || y != tc.expected[i][1] {
+				t.Fatalf("Invalid result for %+v: unexpected tile %d/%d at %d", tc.bounds, x, y, i)
+			}
+			i++
+		}
+		if i != len(tc.expected) || it.Next() {
+			t.Fatalf("Invalid result for %+v: expected %d tiles, got %d", tc.bounds, len(tc.expected), i)
+		}
+	}
+
+	if _, err := geopoint.TilesCovering(geopoint.Rect{MinLat: 10, MaxLat: -10}, 2); err != geopoint.ErrInvalidGeoPointValue {
+		t.Fatalf("Invalid result: expected ErrInvalidGeoPointValue, got %v", err)
+	}
+}
@@ -0,0 +1,32 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utm
+
+import "errors"
+
+var (
+	// ErrOutOfRange is raised when the given point is outside the UTM latitudes
+	ErrOutOfRange = errors.New("utm: point out of UTM range")
+	// ErrInvalidZone is raised when the given zone is not in [1, 60]
+	ErrInvalidZone = errors.New("utm: invalid zone")
+	// ErrInvalidBand is raised when the given latitude band is unknown
+	ErrInvalidBand = errors.New("utm: invalid latitude band")
+	// ErrInvalidMGRS is raised when the given MGRS reference is syntactically invalid
+	ErrInvalidMGRS = errors.New("utm: invalid MGRS reference")
+	// ErrInvalidPrecision is raised when the given MGRS precision is not supported
+	ErrInvalidPrecision = errors.New("utm: invalid MGRS precision")
+)
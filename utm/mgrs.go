@@ -0,0 +1,140 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utm
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Precision is the number of digits of each MGRS coordinate, from 0 (100km
+// grid square) to 5 (1m grid square).
+type Precision int
+
+// MGRS precisions
+const (
+	Precision100km Precision = iota
+	Precision10km
+	Precision1km
+	Precision100m
+	Precision10m
+	Precision1m
+)
+
+// Size returns the size of the grid square, in meters.
+func (p Precision) Size() float64 {
+	return math.Pow10(int(Precision1m - p))
+}
+
+var (
+	// 100km column letters, cycling every 3 zones
+	e100kLetters = [3]string{"ABCDEFGH", "JKLMNPQR", "STUVWXYZ"}
+	// 100km row letters, cycling every 2 zones
+	n100kLetters = [2]string{"ABCDEFGHJKLMNPQRSTUV", "FGHJKLMNPQRSTUVABCDE"}
+)
+
+// MGRS returns the MGRS reference of the grid square containing the
+// coordinate, like "31U DQ 48251 11932".
+func (c Coordinate) MGRS(p Precision) (string, error) {
+	if err := c.check(); err != nil {
+		return "", err
+	}
+	if p < Precision100km || p > Precision1m {
+		return "", ErrInvalidPrecision
+	}
+
+	// 100km grid square
+	col := int(math.Floor(c.Easting / 100000))
+	row := int(math.Floor(c.Northing/100000)) % 20
+	if col < 1 || col > 8 || row < 0 {
+		return "", ErrOutOfRange
+	}
+	square := []byte{e100kLetters[(c.Zone-1)%3][col-1], n100kLetters[(c.Zone-1)%2][row]}
+	if p == Precision100km {
+		return fmt.Sprintf("%02d%c %s", c.Zone, c.Band, square), nil
+	}
+
+	// Truncated coordinates within the grid square
+	size := p.Size()
+	e := int(math.Floor(math.Mod(c.Easting, 100000) / size))
+	n := int(math.Floor(math.Mod(c.Northing, 100000) / size))
+
+	return fmt.Sprintf("%02d%c %s %0*d %0*d", c.Zone, c.Band, square, int(p), e, int(p), n), nil
+}
+
+// ParseMGRS returns the south-west corner and the precision of the given MGRS
+// reference. Spaces are optional and parsing is case-insensitive.
+func ParseMGRS(s string) (Coordinate, Precision, error) {
+	s = strings.ToUpper(strings.Join(strings.Fields(s), ""))
+
+	// Zone
+	i := 0
+	zone := 0
+	for ; i < len(s) && i < 2 && s[i] >= '0' && s[i] <= '9'; i++ {
+		zone = zone*10 + int(s[i]-'0')
+	}
+	if i == 0 || zone < 1 || zone > 60 || len(s) < i+3 {
+		return Coordinate{}, 0, ErrInvalidMGRS
+	}
+
+	// Band and 100km grid square
+	bandIdx := strings.IndexByte(bands, s[i])
+	col := strings.IndexByte(e100kLetters[(zone-1)%3], s[i+1])
+	row := strings.IndexByte(n100kLetters[(zone-1)%2], s[i+2])
+	if bandIdx < 0 || col < 0 || row < 0 {
+		return Coordinate{}, 0, ErrInvalidMGRS
+	}
+
+	// Numerical location
+	digits := s[i+3:]
+	if len(digits)%2 == 1 || len(digits) > 2*int(Precision1m) {
+		return Coordinate{}, 0, ErrInvalidMGRS
+	}
+	for j := 0; j < len(digits); j++ {
+		if digits[j] < '0' || digits[j] > '9' {
+			return Coordinate{}, 0, ErrInvalidMGRS
+		}
+	}
+	p := Precision(len(digits) / 2)
+	e, n := 0.0, 0.0
+	for j := 0; j < int(p); j++ {
+		e = e*10 + float64(digits[j]-'0')
+		n = n*10 + float64(digits[int(p)+j]-'0')
+	}
+
+	c := Coordinate{
+		Zone:     zone,
+		Band:     bands[bandIdx],
+		Easting:  float64(col+1)*100000 + e*p.Size(),
+		Northing: float64(row)*100000 + n*p.Size(),
+	}
+
+	// Row letters cycle every 2000km, pick the cycle closest to the band
+	// center (bands are less than 1400km high).
+	bandCenter := minLatitude + 8*float64(bandIdx) + 4
+	if c.Band == 'X' {
+		bandCenter += 2
+	}
+	_, y := forward(bandCenter*math.Pi/180, 0)
+	if !c.IsNorth() {
+		y += falseNorthing
+	}
+	c.Northing += math.Round((y-c.Northing)/2000000) * 2000000
+
+	return c, p, nil
+}
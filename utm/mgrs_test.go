@@ -0,0 +1,119 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utm_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"go.zenithar.org/geopoint/utm"
+)
+
+func TestCoordinate_MGRS(t *testing.T) {
+	c, err := utm.FromLatLng(48.8582, 2.2945)
+	if err != nil {
+		t.Fatalf("Unable to convert, got error %v", err)
+	}
+
+	testCases := []struct {
+		precision utm.Precision
+		expected  string
+	}{
+		{utm.Precision100km, "31U DQ"},
+		{utm.Precision10km, "31U DQ 4 1"},
+		{utm.Precision1km, "31U DQ 48 11"},
+		{utm.Precision100m, "31U DQ 482 119"},
+		{utm.Precision10m, "31U DQ 4825 1193"},
+		{utm.Precision1m, "31U DQ 48251 11932"},
+	}
+
+	for _, tc := range testCases {
+		out, err := c.MGRS(tc.precision)
+		if err != nil {
+			t.Fatalf("Unable to format %s, got error %v", c, err)
+		}
+		if out != tc.expected {
+			t.Fatalf("Invalid result: expected %q, got %q", tc.expected, out)
+		}
+	}
+
+	if _, err := c.MGRS(utm.Precision1m + 1); err != utm.ErrInvalidPrecision {
+		t.Fatalf("Invalid result: expected ErrInvalidPrecision, got %v", err)
+	}
+}
+
+func TestParseMGRS(t *testing.T) {
+	testCases := []struct {
+		mgrs              string
+		zone              int
+		band              byte
+		easting, northing float64
+		precision         utm.Precision
+	}{
+		{"31U DQ 48251 11932", 31, 'U', 448251, 5411932, utm.Precision1m},
+		{"31udq4825111932", 31, 'U', 448251, 5411932, utm.Precision1m},
+		{"31U DQ 482 119", 31, 'U', 448200, 5411900, utm.Precision100m},
+		{"31U DQ", 31, 'U', 400000, 5400000, utm.Precision100km},
+		{"31N AA 66021 00000", 31, 'N', 166021, 0, utm.Precision1m},
+		{"4Q FJ 12345 67890", 4, 'Q', 612345, 2367890, utm.Precision1m},
+	}
+
+	for _, tc := range testCases {
+		c, p, err := utm.ParseMGRS(tc.mgrs)
+		if err != nil {
+			t.Fatalf("Unable to parse %q, got error %v", tc.mgrs, err)
+		}
+		expected := utm.Coordinate{Zone: tc.zone, Band: tc.band, Easting: tc.easting, Northing: tc.northing}
+		if c != expected || p != tc.precision {
+			t.Fatalf("Invalid result for %q: expected %s (%d), got %s (%d)", tc.mgrs, expected, tc.precision, c, p)
+		}
+	}
+}
+
+func TestParseMGRS_Invalid(t *testing.T) {
+	for _, mgrs := range []string{"", "31", "31U", "31UD", "0U DQ", "61U DQ", "31I DQ", "31U IQ", "31U DW", "31U DQ 4825 1193 1", "31U DQ 482511 119321", "31U DQ 4825A 11932", "131U DQ"} {
+		if _, _, err := utm.ParseMGRS(mgrs); err != utm.ErrInvalidMGRS {
+			t.Fatalf("Invalid result for %q: expected ErrInvalidMGRS, got %v", mgrs, err)
+		}
+	}
+}
+
+func TestMGRS_RoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100000; i++ {
+		c, err := utm.FromLatLng(r.Float64()*164-80, r.Float64()*360-180)
+		if err != nil {
+			t.Fatalf("Unable to convert, got error %v", err)
+		}
+
+		mgrs, err := c.MGRS(utm.Precision1m)
+		if err != nil {
+			t.Fatalf("Unable to format %s, got error %v", c, err)
+		}
+		out, _, err := utm.ParseMGRS(mgrs)
+		if err != nil {
+			t.Fatalf("Unable to parse %q, got error %v", mgrs, err)
+		}
+
+		// The 2000km northing cycle must be recovered from the band
+		expected := utm.Coordinate{Zone: c.Zone, Band: c.Band, Easting: math.Floor(c.Easting), Northing: math.Floor(c.Northing)}
+		if out != expected {
+			t.Fatalf("Invalid round trip for %q, expected %s, got %s", mgrs, expected, out)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utm
+
+import "math"
+
+// WGS84 transverse Mercator projection, using the Krüger series to the 6th
+// order of the third flattening, accurate to a few nanometers within the UTM
+// zones.
+//
+// See C. F. F. Karney, "Transverse Mercator with an accuracy of a few
+// nanometers", J. Geodesy 85(8), 475-485 (2011).
+const (
+	// WGS84 ellipsoid
+	equatorialRadius = 6378137.0
+	flattening       = 1 / 298.257223563
+
+	// UTM scale factor on the central meridian
+	k0 = 0.9996
+
+	falseEasting  = 500000.0
+	falseNorthing = 10000000.0
+)
+
+var (
+	// Eccentricity
+	eccentricity = math.Sqrt(flattening * (2 - flattening))
+	// Rectifying radius, scaled by k0
+	rectifyingRadius float64
+	// Krüger series coefficients, forward and inverse
+	alpha, beta [6]float64
+)
+
+func init() {
+	n := flattening / (2 - flattening)
+	n2, n3, n4, n5, n6 := n*n, n*n*n, n*n*n*n, n*n*n*n*n, n*n*n*n*n*n
+
+	rectifyingRadius = k0 * equatorialRadius / (1 + n) * (1 + n2/4 + n4/64 + n6/256)
+
+	alpha = [6]float64{
+		n/2 - 2*n2/3 + 5*n3/16 + 41*n4/180 - 127*n5/288 + 7891*n6/37800,
+		13*n2/48 - 3*n3/5 + 557*n4/1440 + 281*n5/630 - 1983433*n6/1935360,
+		61*n3/240 - 103*n4/140 + 15061*n5/26880 + 167603*n6/181440,
+		49561*n4/161280 - 179*n5/168 + 6601661*n6/7257600,
+		34729*n5/80640 - 3418889*n6/1995840,
+		212378941 * n6 / 319334400,
+	}
+	beta = [6]float64{
+		n/2 - 2*n2/3 + 37*n3/96 - n4/360 - 81*n5/512 + 96199*n6/604800,
+		n2/48 + n3/15 - 437*n4/1440 + 46*n5/105 - 1118711*n6/3870720,
+		17*n3/480 - 37*n4/840 - 209*n5/4480 + 5569*n6/90720,
+		4397*n4/161280 - 11*n5/504 - 830251*n6/7257600,
+		4583*n5/161280 - 108847*n6/3991680,
+		20648693 * n6 / 638668800,
+	}
+}
+
+// forward projects the given point, in radians, relative to the central
+// meridian. It returns the coordinates from the central meridian and the
+// equator, in meters.
+func forward(phi, lambda float64) (x, y float64) {
+	// Conformal latitude
+	tau := math.Tan(phi)
+	sigma := math.Sinh(eccentricity * math.Atanh(eccentricity*tau/math.Sqrt(1+tau*tau)))
+	taup := tau*math.Sqrt(1+sigma*sigma) - sigma*math.Sqrt(1+tau*tau)
+
+	// Spherical transverse Mercator
+	xip := math.Atan2(taup, math.Cos(lambda))
+	etap := math.Asinh(math.Sin(lambda) / math.Sqrt(taup*taup+math.Cos(lambda)*math.Cos(lambda)))
+
+	// Ellipsoidal correction
+	xi, eta := xip, etap
+	for j := 1; j <= len(alpha); j++ {
+		a, k := alpha[j-1], float64(2*j)
+		xi += a * math.Sin(k*xip) * math.Cosh(k*etap)
+		eta += a * math.Cos(k*xip) * math.Sinh(k*etap)
+	}
+
+	return rectifyingRadius * eta, rectifyingRadius * xi
+}
+
+// inverse returns the point, in radians, relative to the central meridian of
+// the given projected coordinates.
+func inverse(x, y float64) (phi, lambda float64) {
+	xi, eta := y/rectifyingRadius, x/rectifyingRadius
+
+	// Ellipsoidal correction
+	xip, etap := xi, eta
+	for j := 1; j <= len(beta); j++ {
+		b, k := beta[j-1], float64(2*j)
+		xip -= b * math.Sin(k*xi) * math.Cosh(k*eta)
+		etap -= b * math.Cos(k*xi) * math.Sinh(k*eta)
+	}
+
+	// Spherical transverse Mercator
+	sinhEtap, sinXip, cosXip := math.Sinh(etap), math.Sin(xip), math.Cos(xip)
+	taup := sinXip / math.Sqrt(sinhEtap*sinhEtap+cosXip*cosXip)
+
+	// Conformal latitude to geodetic latitude, by Newton's method
+	e2 := eccentricity * eccentricity
+	tau := taup
+	for i := 0; i < 10; i++ {
+		sigma := math.Sinh(eccentricity * math.Atanh(eccentricity*tau/math.Sqrt(1+tau*tau)))
+		taui := tau*math.Sqrt(1+sigma*sigma) - sigma*math.Sqrt(1+tau*tau)
+		delta := (taup - taui) / math.Sqrt(1+taui*taui) * (1 + (1-e2)*tau*tau) / ((1 - e2) * math.Sqrt(1+tau*tau))
+		tau += delta
+		if math.Abs(delta) < 1e-12 {
+			break
+		}
+	}
+
+	return math.Atan(tau), math.Atan2(sinhEtap, cosXip)
+}
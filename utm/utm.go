@@ -0,0 +1,174 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package utm converts coordinates between WGS84 latitude/longitude, the
+// Universal Transverse Mercator (UTM) projection and the Military Grid
+// Reference System (MGRS).
+//
+// UTM splits the world in 60 zones of 6° of longitude, and 20 latitude bands
+// of 8° (12° for the northernmost one) from 80°S to 84°N. Polar regions use the
+// UPS projection, which is not supported.
+package utm
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	// Latitude bands, from 80°S
+	bands = "CDEFGHJKLMNPQRSTUVWX"
+
+	minLatitude = -80
+	maxLatitude = 84
+)
+
+// Coordinate is a UTM coordinate.
+type Coordinate struct {
+	// Zone is the longitude zone, in [1, 60]
+	Zone int
+	// Band is the latitude band letter, from 'C' to 'X'. Bands from 'N' are in
+	// the northern hemisphere.
+	Band byte
+	// Easting and Northing are expressed in meters, with the UTM false easting
+	// and false northing
+	Easting, Northing float64
+}
+
+// FromLatLng returns the UTM coordinate of the given point, in degrees. The
+// zone follows the Norway and Svalbard exceptions.
+func FromLatLng(lat, lon float64) (Coordinate, error) {
+	if math.IsNaN(lat) || lat < minLatitude || lat > maxLatitude {
+		return Coordinate{}, ErrOutOfRange
+	}
+	if math.IsNaN(lon) || math.IsInf(lon, 0) {
+		return Coordinate{}, ErrOutOfRange
+	}
+
+	// Normalize longitude to [-180, 180[
+	lon = math.Mod(lon+180, 360)
+	if lon < 0 {
+		lon += 360
+	}
+	lon -= 180
+
+	band := latitudeBand(lat)
+	zone := longitudeZone(lat, lon)
+
+	x, y := forward(lat*math.Pi/180, (lon-centralMeridian(zone))*math.Pi/180)
+	c := Coordinate{
+		Zone:     zone,
+		Band:     band,
+		Easting:  x + falseEasting,
+		Northing: y,
+	}
+	if !c.IsNorth() {
+		c.Northing += falseNorthing
+	}
+
+	return c, nil
+}
+
+// IsNorth returns true if the coordinate is in the northern hemisphere.
+func (c Coordinate) IsNorth() bool {
+	return c.Band >= 'N'
+}
+
+// LatLng returns the point of the coordinate, in degrees.
+func (c Coordinate) LatLng() (lat, lon float64, err error) {
+	if err := c.check(); err != nil {
+		return 0, 0, err
+	}
+
+	y := c.Northing
+	if !c.IsNorth() {
+		y -= falseNorthing
+	}
+	phi, lambda := inverse(c.Easting-falseEasting, y)
+
+	lat = phi * 180 / math.Pi
+	lon = lambda*180/math.Pi + centralMeridian(c.Zone)
+	if lon >= 180 {
+		lon -= 360
+	} else if lon < -180 {
+		lon += 360
+	}
+
+	return lat, lon, nil
+}
+
+// String returns the coordinate with a millimeter precision, like
+// "31N 166021.443 0.000".
+func (c Coordinate) String() string {
+	return fmt.Sprintf("%d%c %.3f %.3f", c.Zone, c.Band, c.Easting, c.Northing)
+}
+
+// -----------------------------------------------------------------------------
+
+// check returns nil if zone and band are valid.
+func (c Coordinate) check() error {
+	if c.Zone < 1 || c.Zone > 60 {
+		return ErrInvalidZone
+	}
+	if strings.IndexByte(bands, c.Band) < 0 {
+		return ErrInvalidBand
+	}
+	return nil
+}
+
+// latitudeBand returns the band letter of the given latitude.
+func latitudeBand(lat float64) byte {
+	idx := int(math.Floor((lat - minLatitude) / 8))
+	if idx >= len(bands) {
+		// X band is extended to 84°N
+		idx = len(bands) - 1
+	}
+	return bands[idx]
+}
+
+// longitudeZone returns the zone of the given point.
+func longitudeZone(lat, lon float64) int {
+	zone := int(math.Floor((lon+180)/6)) + 1
+	if zone > 60 {
+		zone = 60
+	}
+
+	switch {
+	case lat >= 56 && lat < 64 && lon >= 3 && lon < 12:
+		// Norway
+		zone = 32
+	case lat >= 72 && lon >= 0 && lon < 42:
+		// Svalbard
+		switch {
+		case lon < 9:
+			zone = 31
+		case lon < 21:
+			zone = 33
+		case lon < 33:
+			zone = 35
+		default:
+			zone = 37
+		}
+	}
+
+	return zone
+}
+
+// centralMeridian returns the longitude of the central meridian of the zone.
+func centralMeridian(zone int) float64 {
+	return float64(zone-1)*6 - 180 + 3
+}
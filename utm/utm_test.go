@@ -0,0 +1,144 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utm_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"go.zenithar.org/geopoint/utm"
+)
+
+func TestFromLatLng(t *testing.T) {
+	testCases := []struct {
+		lat, lon          float64
+		zone              int
+		band              byte
+		easting, northing float64
+	}{
+		{0, 0, 31, 'N', 166021.443, 0},
+		{48.8582, 2.2945, 31, 'U', 448251.795, 5411932.678},
+		{1, 1, 31, 'N', 277438.263, 110597.973},
+		{-1, -1, 30, 'M', 722561.737, 9889402.027},
+		// Normalized longitude
+		{0, 360, 31, 'N', 166021.443, 0},
+		{0, 180, 1, 'N', 166021.443, 0},
+	}
+
+	for _, tc := range testCases {
+		c, err := utm.FromLatLng(tc.lat, tc.lon)
+		if err != nil {
+			t.Fatalf("Unable to convert (%f, %f), got error %v", tc.lat, tc.lon, err)
+		}
+		if c.Zone != tc.zone || c.Band != tc.band || math.Abs(c.Easting-tc.easting) > 1e-3 || math.Abs(c.Northing-tc.northing) > 1e-3 {
+			t.Fatalf("Invalid result for (%f, %f): expected %d%c %.3f %.3f, got %s", tc.lat, tc.lon, tc.zone, tc.band, tc.easting, tc.northing, c)
+		}
+	}
+
+	c, _ := utm.FromLatLng(48.8582, 2.2945)
+	if out := c.String(); out != "31U 448251.795 5411932.678" {
+		t.Fatalf("Invalid result: expected %q, got %q", "31U 448251.795 5411932.678", out)
+	}
+}
+
+func TestFromLatLng_Zones(t *testing.T) {
+	testCases := []struct {
+		lat, lon float64
+		zone     int
+		band     byte
+	}{
+		{-80, 0, 31, 'C'},
+		{-0.000001, 0, 31, 'M'},
+		{0, -0.000001, 30, 'N'},
+		{84, 0, 31, 'X'},
+		{71.9, 8, 32, 'W'},
+		// Norway
+		{60, 2.9, 31, 'V'},
+		{60, 3, 32, 'V'},
+		{63.9, 11.9, 32, 'V'},
+		{64, 3, 31, 'W'},
+		// Svalbard
+		{78, 8, 31, 'X'},
+		{78, 10, 33, 'X'},
+		{78, 22, 35, 'X'},
+		{78, 40, 37, 'X'},
+		{78, 43, 38, 'X'},
+	}
+
+	for _, tc := range testCases {
+		c, err := utm.FromLatLng(tc.lat, tc.lon)
+		if err != nil {
+			t.Fatalf("Unable to convert (%f, %f), got error %v", tc.lat, tc.lon, err)
+		}
+		if c.Zone != tc.zone || c.Band != tc.band {
+			t.Fatalf("Invalid result for (%f, %f): expected %d%c, got %d%c", tc.lat, tc.lon, tc.zone, tc.band, c.Zone, c.Band)
+		}
+	}
+
+	for _, lat := range []float64{-80.000001, 84.000001, math.NaN()} {
+		if _, err := utm.FromLatLng(lat, 0); err != utm.ErrOutOfRange {
+			t.Fatalf("Invalid result for %f: expected ErrOutOfRange, got %v", lat, err)
+		}
+	}
+}
+
+func TestCoordinate_LatLng(t *testing.T) {
+	testCases := []struct {
+		c        utm.Coordinate
+		expected error
+	}{
+		{utm.Coordinate{Zone: 0, Band: 'N'}, utm.ErrInvalidZone},
+		{utm.Coordinate{Zone: 61, Band: 'N'}, utm.ErrInvalidZone},
+		{utm.Coordinate{Zone: 31, Band: 'I'}, utm.ErrInvalidBand},
+		{utm.Coordinate{Zone: 31, Band: 'n'}, utm.ErrInvalidBand},
+	}
+
+	for _, tc := range testCases {
+		if _, _, err := tc.c.LatLng(); err != tc.expected {
+			t.Fatalf("Invalid result for %s: expected %v, got %v", tc.c, tc.expected, err)
+		}
+	}
+}
+
+func TestUTM_RoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100000; i++ {
+		lat, lon := r.Float64()*164-80, r.Float64()*360-180
+
+		c, err := utm.FromLatLng(lat, lon)
+		if err != nil {
+			t.Fatalf("Unable to convert (%f, %f), got error %v", lat, lon, err)
+		}
+		outLat, outLon, err := c.LatLng()
+		if err != nil {
+			t.Fatalf("Unable to convert %s, got error %v", c, err)
+		}
+
+		// 1e-8° is about 1mm
+		if math.Abs(outLat-lat) > 1e-8 || math.Abs(outLon-lon) > 1e-8 {
+			t.Fatalf("Invalid round trip for (%.9f, %.9f), got (%.9f, %.9f)", lat, lon, outLat, outLon)
+		}
+		out, err := utm.FromLatLng(outLat, outLon)
+		if err != nil {
+			t.Fatalf("Unable to convert (%f, %f), got error %v", outLat, outLon, err)
+		}
+		if out.Zone != c.Zone || math.Abs(out.Easting-c.Easting) > 1e-3 || math.Abs(out.Northing-c.Northing) > 1e-3 {
+			t.Fatalf("Invalid round trip for %s, got %s", c, out)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utm
+
+import "go.zenithar.org/geopoint"
+
+// FromValue returns the UTM coordinate of the given point. Points with a
+// precision level are located with the south-west corner of their cell.
+func FromValue(v geopoint.Value) (Coordinate, error) {
+	lat, lon, err := geopoint.Decode(v)
+	if err != nil {
+		return Coordinate{}, err
+	}
+
+	return FromLatLng(lat, lon)
+}
+
+// Value returns the point of the coordinate, rounded to the micro-degree.
+func (c Coordinate) Value() (geopoint.Value, error) {
+	lat, lon, err := c.LatLng()
+	if err != nil {
+		return geopoint.Empty, err
+	}
+
+	return geopoint.Encode(lat, lon), nil
+}
+
+// ToMGRS returns the MGRS reference of the given point.
+func ToMGRS(v geopoint.Value, p Precision) (string, error) {
+	c, err := FromValue(v)
+	if err != nil {
+		return "", err
+	}
+
+	return c.MGRS(p)
+}
+
+// FromMGRS returns the center of the grid square of the given MGRS reference.
+func FromMGRS(s string) (geopoint.Value, error) {
+	c, p, err := ParseMGRS(s)
+	if err != nil {
+		return geopoint.Empty, err
+	}
+
+	c.Easting += p.Size() / 2
+	c.Northing += p.Size() / 2
+
+	return c.Value()
+}
@@ -0,0 +1,89 @@
+/*
+ * Copyright 2019 Thibault NORMAND
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utm_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"go.zenithar.org/geopoint"
+	"go.zenithar.org/geopoint/utm"
+)
+
+func TestFromValue(t *testing.T) {
+	c, err := utm.FromValue(geopoint.Encode(48.8582, 2.2945))
+	if err != nil {
+		t.Fatalf("Unable to convert, got error %v", err)
+	}
+	if out := c.String(); out != "31U 448251.795 5411932.678" {
+		t.Fatalf("Invalid result: expected %q, got %q", "31U 448251.795 5411932.678", out)
+	}
+
+	if _, err := utm.FromValue(geopoint.Empty); err != geopoint.ErrEmptyGeoPoint {
+		t.Fatalf("Invalid result: expected ErrEmptyGeoPoint, got %v", err)
+	}
+	if _, err := utm.FromValue(geopoint.Encode(85, 0)); err != utm.ErrOutOfRange {
+		t.Fatalf("Invalid result: expected ErrOutOfRange, got %v", err)
+	}
+}
+
+func TestCoordinate_Value(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100000; i++ {
+		p := geopoint.Encode(r.Float64()*164-80, r.Float64()*360-180)
+
+		c, err := utm.FromValue(p)
+		if err != nil {
+			t.Fatalf("Unable to convert %d, got error %v", p, err)
+		}
+
+		// Projection error is far below the micro-degree
+		out, err := c.Value()
+		if err != nil {
+			t.Fatalf("Unable to convert %s, got error %v", c, err)
+		}
+		if out != p {
+			t.Fatalf("Invalid round trip for %s, expected %d, got %d", c, p, out)
+		}
+	}
+}
+
+func TestMGRS_Value(t *testing.T) {
+	p := geopoint.Encode(48.8582, 2.2945)
+	for precision := utm.Precision100km; precision <= utm.Precision1m; precision++ {
+		mgrs, err := utm.ToMGRS(p, precision)
+		if err != nil {
+			t.Fatalf("Unable to format %d, got error %v", p, err)
+		}
+		out, err := utm.FromMGRS(mgrs)
+		if err != nil {
+			t.Fatalf("Unable to parse %q, got error %v", mgrs, err)
+		}
+
+		// Grid square center is closer than half a square diagonal
+		c1, _ := utm.FromValue(p)
+		c2, _ := utm.FromValue(out)
+		if d := math.Hypot(c1.Easting-c2.Easting, c1.Northing-c2.Northing); d > precision.Size()*math.Sqrt2/2 {
+			t.Fatalf("Invalid result for %q: center is %fm away", mgrs, d)
+		}
+	}
+
+	if _, err := utm.FromMGRS("31U DQ 4"); err != utm.ErrInvalidMGRS {
+		t.Fatalf("Invalid result: expected ErrInvalidMGRS, got %v", err)
+	}
+}
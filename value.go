@@ -17,7 +17,12 @@
 package geopoint
 
 import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
 	"fmt"
+	"math"
+	"strconv"
 )
 
 // Value is a type wrapper to define a GPS point
@@ -25,18 +30,179 @@ type Value uint64
 
 var (
 	// Zero value for comparison
+	//
+	// Deprecated: Zero is a valid point near (-90, -180), use Empty to denote
+	// the absence of point.
 	Zero = Value(0)
+	// Empty is the invalid point used to denote the absence of point, Encode
+	// never produces it (its version nibble is reserved).
+	Empty = Value(math.MaxUint64)
 )
 
-// Code returns the point encoded as hexadecimal string
+// IsValid returns true if the point is not empty and could be decoded
+func (p Value) IsValid() bool {
+	return validate(p) == nil
+}
+
+// Code returns the point encoded as hexadecimal string. Only significant
+// digits of points with a precision level are printed, the empty point has no
+// code.
 func (p Value) Code() string {
+	if p == Empty {
+		return ""
+	}
+
+	var buf [24]byte
+	return string(p.appendCode(buf[:0]))
+}
+
+// appendCode appends the point hexadecimal code to the given buffer
+func (p Value) appendCode(b []byte) []byte {
 	value := uint64(p)
-	return fmt.Sprintf("%05X:%05X:%05X", (value >> 40), (value>>20)&0xFFFFF, (value)&0xFFFFF)
+
+	digits := p.codeDigits()
+	low := (value & 0xFFFFFFFFFF) >> (40 - 4*uint(digits))
+
+	b = appendHex(b, value>>40, 5)
+	b = append(b, ':')
+	switch {
+	case digits == 10:
+		b = appendHex(b, (value>>20)&0xFFFFF, 5)
+		b = append(b, ':')
+		b = appendHex(b, value&0xFFFFF, 5)
+	case digits > 5:
+		b = appendHex(b, low>>(4*uint(digits-5)), 5)
+		b = append(b, ':')
+		b = appendHex(b, low&(1<<(4*uint(digits-5))-1), digits-5)
+	default:
+		b = appendHex(b, low, digits)
+	}
+
+	return b
+}
+
+// appendHex appends x as upper case hexadecimal, zero padded to width digits
+func appendHex(b []byte, x uint64, width int) []byte {
+	const hexDigits = "0123456789ABCDEF"
+
+	n := 1
+	for y := x >> 4; y > 0; y >>= 4 {
+		n++
+	}
+	for ; width > n; width-- {
+		b = append(b, '0')
+	}
+	for i := n - 1; i >= 0; i-- {
+		b = append(b, hexDigits[(x>>(4*uint(i)))&0xF])
+	}
+
+	return b
 }
 
 // -----------------------------------------------------------------------------
 
-// MarshalJSON is used to override JSON marshalling strategy of uint64
+// MarshalJSON is used to override JSON marshalling strategy of uint64, the
+// empty point is marshalled as null.
 func (p Value) MarshalJSON() ([]byte, error) {
+	if p == Empty {
+		return []byte("null"), nil
+	}
 	return []byte(fmt.Sprintf("\"%s\"", p.Code())), nil
 }
+
+// UnmarshalJSON decodes a point from its JSON representation. The point could
+// be given as its encoded string, its raw integer value, or as an object with
+// `lat` and `lon` attributes. null is decoded as the empty point.
+func (p *Value) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+
+	// Zero value is a valid point, null must be explicit
+	if string(data) == "null" {
+		*p = Empty
+		return nil
+	}
+	if len(data) == 0 {
+		return ErrInvalidGeoPointValue
+	}
+
+	switch data[0] {
+	case '"':
+		var code string
+		if err := json.Unmarshal(data, &code); err != nil {
+			return err
+		}
+		return p.UnmarshalText([]byte(code))
+	case '{':
+		var coords struct {
+			Lat *float64 `json:"lat"`
+			Lon *float64 `json:"lon"`
+		}
+		if err := json.Unmarshal(data, &coords); err != nil {
+			return err
+		}
+		if coords.Lat == nil || coords.Lon == nil {
+			return ErrInvalidGeoPointValue
+		}
+		value, err := EncodeChecked(*coords.Lat, *coords.Lon)
+		if err != nil {
+			return err
+		}
+		*p = value
+	default:
+		raw, err := strconv.ParseUint(string(data), 10, 64)
+		if err != nil {
+			return ErrInvalidGeoPointValue
+		}
+		if err := validate(Value(raw)); err != nil {
+			return err
+		}
+		*p = Value(raw)
+	}
+
+	return nil
+}
+
+// MarshalText encodes the point as its hexadecimal code
+func (p Value) MarshalText() ([]byte, error) {
+	return []byte(p.Code()), nil
+}
+
+// UnmarshalText decodes a point from its hexadecimal code, an empty text is
+// decoded as the empty point.
+func (p *Value) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*p = Empty
+		return nil
+	}
+
+	value, err := ParseCode(string(text))
+	if err != nil {
+		return err
+	}
+	*p = value
+	return nil
+}
+
+// MarshalBinary encodes the point as a 8-byte big-endian integer
+func (p Value) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint64(data, uint64(p))
+	return data, nil
+}
+
+// UnmarshalBinary decodes a point from a 8-byte big-endian integer
+func (p *Value) UnmarshalBinary(data []byte) error {
+	if len(data) != 8 {
+		return ErrInvalidGeoPointValue
+	}
+	value := Value(binary.BigEndian.Uint64(data))
+	if value == Empty {
+		*p = Empty
+		return nil
+	}
+	if err := validate(value); err != nil {
+		return err
+	}
+	*p = value
+	return nil
+}
@@ -19,6 +19,7 @@ package geopoint_test
 import (
 	"bytes"
 	"encoding/json"
+	"encoding/xml"
 	"testing"
 
 	"go.zenithar.org/geopoint"
@@ -81,3 +82,229 @@ func TestValue_MarshallJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestValue_UnmarshalJSON(t *testing.T) {
+	tcl := []struct {
+		name          string
+		input         string
+		expectedPoint geopoint.Value
+		expectedErr   error
+	}{
+		{
+			name:          "Encoded string",
+			input:         `"10AB5:69A51:94D36"`,
+			expectedPoint: geopoint.Value(75071809151126838),
+		},
+		{
+			name:          "Raw number",
+			input:         `75071809151126838`,
+			expectedPoint: geopoint.Value(75071809151126838),
+		},
+		{
+			name:          "Coordinates object",
+			input:         `{"lat": 43.603574, "lon": 1.442917}`,
+			expectedPoint: geopoint.Value(75071809151126838),
+		},
+		{
+			name:        "Invalid string",
+			input:       `"10AB5:69A51:94D36:0"`,
+			expectedErr: geopoint.ErrInvalidGeoPointHash,
+		},
+		{
+			name:        "Out of range fraction",
+			input:       `"10AB5:FFFFF:FFFFF"`,
+			expectedErr: geopoint.ErrInvalidGeoPointValue,
+		},
+		{
+			name:        "Unknown version",
+			input:       `18446744073709551615`,
+			expectedErr: geopoint.ErrUnsupportedVersion,
+		},
+		{
+			name:        "Negative number",
+			input:       `-1`,
+			expectedErr: geopoint.ErrInvalidGeoPointValue,
+		},
+		{
+			name:        "Missing longitude",
+			input:       `{"lat": 43.603574}`,
+			expectedErr: geopoint.ErrInvalidGeoPointValue,
+		},
+		{
+			name:        "Out of range latitude",
+			input:       `{"lat": 143.603574, "lon": 1.442917}`,
+			expectedErr: geopoint.ErrLatitudeOutOfRange(143.603574),
+		},
+	}
+
+	for _, tc := range tcl {
+		t.Run(tc.name, func(t *testing.T) {
+			var p geopoint.Value
+			err := json.Unmarshal([]byte(tc.input), &p)
+			if err != tc.expectedErr {
+				t.Fatalf("Invalid result: Error expected %v, got %v.", tc.expectedErr, err)
+			}
+			if p != tc.expectedPoint {
+				t.Fatalf("Invalid result: expected %d but got %d", tc.expectedPoint, uint64(p))
+			}
+		})
+	}
+}
+
+func TestValue_JSONRoundTrip(t *testing.T) {
+	type payload struct {
+		Point  geopoint.Value            `json:"point"`
+		Points map[geopoint.Value]string `json:"points"`
+	}
+
+	in := payload{
+		Point: geopoint.Value(75071809151126838),
+		Points: map[geopoint.Value]string{
+			geopoint.Value(75071809151126838): "Place du capitole",
+			geopoint.Value(77887690747650097): "Tour Eiffel",
+		},
+	}
+
+	body, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("unable to marshal json, got error %v", err)
+	}
+
+	var out payload
+	if err := json.Unmarshal(body, &out); err != nil {
+		t.Fatalf("unable to unmarshal json, got error %v", err)
+	}
+	if out.Point != in.Point {
+		t.Fatalf("invalid point, expected %d, got %d", in.Point, out.Point)
+	}
+	for k, v := range in.Points {
+		if out.Points[k] != v {
+			t.Fatalf("invalid map entry for %s, expected %q, got %q", k.Code(), v, out.Points[k])
+		}
+	}
+}
+
+func TestValue_Text(t *testing.T) {
+	type payload struct {
+		Point geopoint.Value `xml:"point,attr"`
+	}
+
+	in := payload{Point: geopoint.Value(75071809151126838)}
+	body, err := xml.Marshal(in)
+	if err != nil {
+		t.Fatalf("unable to marshal xml, got error %v", err)
+	}
+	if expected := `<payload point="10AB5:69A51:94D36"></payload>`; string(body) != expected {
+		t.Fatalf("invalid xml serialization, expected %s, got %s", expected, string(body))
+	}
+
+	var out payload
+	if err := xml.Unmarshal(body, &out); err != nil {
+		t.Fatalf("unable to unmarshal xml, got error %v", err)
+	}
+	if out.Point != in.Point {
+		t.Fatalf("invalid point, expected %d, got %d", in.Point, out.Point)
+	}
+
+	var p geopoint.Value
+	if err := p.UnmarshalText([]byte("ZZZZZ:69A51:94D36")); err != geopoint.ErrInvalidGeoPointHash {
+		t.Fatalf("invalid error, expected %v, got %v", geopoint.ErrInvalidGeoPointHash, err)
+	}
+}
+
+func TestValue_Binary(t *testing.T) {
+	in := geopoint.Value(75071809151126838)
+
+	body, err := in.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unable to marshal binary, got error %v", err)
+	}
+	if expected := []byte{0x01, 0x0A, 0xB5, 0x69, 0xA5, 0x19, 0x4D, 0x36}; !bytes.Equal(body, expected) {
+		t.Fatalf("invalid binary serialization, expected %x, got %x", expected, body)
+	}
+
+	var out geopoint.Value
+	if err := out.UnmarshalBinary(body); err != nil {
+		t.Fatalf("unable to unmarshal binary, got error %v", err)
+	}
+	if out != in {
+		t.Fatalf("invalid point, expected %d, got %d", in, out)
+	}
+
+	if err := out.UnmarshalBinary(body[:7]); err != geopoint.ErrInvalidGeoPointValue {
+		t.Fatalf("invalid error, expected %v, got %v", geopoint.ErrInvalidGeoPointValue, err)
+	}
+	if err := out.UnmarshalBinary([]byte{0xFF, 0, 0, 0, 0, 0, 0, 0}); err != geopoint.ErrUnsupportedVersion {
+		t.Fatalf("invalid error, expected %v, got %v", geopoint.ErrUnsupportedVersion, err)
+	}
+}
+
+func TestValue_Empty(t *testing.T) {
+	p := geopoint.Empty
+
+	if p.Code() != "" {
+		t.Fatalf("empty point should not have a code, got %s", p.Code())
+	}
+	if p.IsValid() {
+		t.Fatal("empty point should not be valid")
+	}
+	if _, _, err := geopoint.Decode(p); err != geopoint.ErrEmptyGeoPoint {
+		t.Fatalf("invalid error, expected %v, got %v", geopoint.ErrEmptyGeoPoint, err)
+	}
+	if p.Truncate(geopoint.LevelDegree) != geopoint.Empty {
+		t.Fatal("truncated empty point should be empty")
+	}
+
+	// JSON
+	type payload struct {
+		Point geopoint.Value `json:"point"`
+	}
+	body, err := json.Marshal(payload{Point: p})
+	if err != nil {
+		t.Fatalf("unable to marshal json, got error %v", err)
+	}
+	if expected := `{"point":null}`; string(body) != expected {
+		t.Fatalf("invalid json serialization, expected %s, got %s", expected, string(body))
+	}
+	var out payload
+	if err := json.Unmarshal(body, &out); err != nil {
+		t.Fatalf("unable to unmarshal json, got error %v", err)
+	}
+	if out.Point != geopoint.Empty {
+		t.Fatalf("null should be decoded as the empty point, got %d", out.Point)
+	}
+
+	// Binary
+	data, err := p.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unable to marshal binary, got error %v", err)
+	}
+	var bin geopoint.Value
+	if err := bin.UnmarshalBinary(data); err != nil || bin != geopoint.Empty {
+		t.Fatalf("invalid binary round trip, got %d (%v)", bin, err)
+	}
+}
+
+func TestValue_IsValid(t *testing.T) {
+	tcl := []struct {
+		name     string
+		point    geopoint.Value
+		expected bool
+	}{
+		{name: "Place du capitole", point: geopoint.Encode(43.603574, 1.442917), expected: true},
+		{name: "South pole at antimeridian", point: geopoint.Zero, expected: true},
+		{name: "North pole", point: geopoint.Encode(90, 179.999999), expected: true},
+		{name: "Truncated", point: geopoint.Encode(43.603574, 1.442917).Truncate(3), expected: true},
+		{name: "Empty", point: geopoint.Empty, expected: false},
+		{name: "Above north pole", point: geopoint.Encode(90, 0) + 1, expected: false},
+		{name: "Unknown version", point: geopoint.Value(uint64(1) << 60), expected: false},
+	}
+
+	for _, tc := range tcl {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.point.IsValid() != tc.expected {
+				t.Fatalf("invalid result for %d, expected %v", tc.point, tc.expected)
+			}
+		})
+	}
+}